@@ -0,0 +1,168 @@
+package dependor
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// Compares the external packages each file imports with the dependencies declared in its
+// nearest package.json. Reports packages that are imported but not declared, declared
+// but never imported, and devDependencies imported from production code. Files matching
+// the `testFilePatterns` config option are allowed to import devDependencies, and any file
+// can import their types. Workspace packages are checked by CheckPackageDependencies
+// instead. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) Audit() []PackageDependencyIssue {
+	// maps package.json paths to the packages their files import and the files importing them
+	imported := make(map[string]map[string]utils.Set[string], 0)
+	// the same as imported, but only for imports that aren't type-only. TypeScript removes
+	// type-only imports when it compiles a file.
+	importedAtRuntime := make(map[string]map[string]utils.Set[string], 0)
+	// package.json paths whose packages have .jsx or .tsx files
+	hasJSX := make(utils.Set[string], 0)
+	for file, edges := range graph.edgeDetails {
+		manifestPath := graph.nearestManifest(file)
+		if manifestPath == "" {
+			continue
+		}
+		if imported[manifestPath] == nil {
+			imported[manifestPath] = make(map[string]utils.Set[string], 0)
+			importedAtRuntime[manifestPath] = make(map[string]utils.Set[string], 0)
+		}
+		if extension := filepath.Ext(file); extension == ".jsx" || extension == ".tsx" {
+			hasJSX.Add(manifestPath)
+		}
+		for _, edge := range edges {
+			if edge.Kind != ExternalPackage {
+				continue
+			}
+			addImportingFile(imported[manifestPath], edge.Package, file)
+			if !edge.TypeOnly {
+				addImportingFile(importedAtRuntime[manifestPath], edge.Package, file)
+			}
+		}
+	}
+
+	issues := make([]PackageDependencyIssue, 0)
+	for manifestPath, packages := range imported {
+		manifest, err := readPackageManifest(manifestPath)
+		if err != nil {
+			fmt.Printf("WARN: Skipping %q in the audit because it could not be read. See error: %s\n", manifestPath, err)
+			continue
+		}
+		owner := manifest.Name
+		if owner == "" {
+			owner = manifestPath
+		}
+
+		for name, files := range packages {
+			importingFiles := files.Keys()
+			slices.Sort(importingFiles)
+			switch {
+			case !manifest.declares(name, importedAtRuntime[manifestPath][name] == nil):
+				issues = append(issues, PackageDependencyIssue{Kind: UndeclaredDependency, Package: owner, Dependency: name, Files: importingFiles})
+			case manifest.isDevDependency(name) && importedAtRuntime[manifestPath][name] != nil:
+				productionFiles := importedAtRuntime[manifestPath][name].Keys()
+				slices.Sort(productionFiles)
+				productionFiles = slices.DeleteFunc(productionFiles, graph.config.IsTestFile)
+				if len(productionFiles) > 0 {
+					issues = append(issues, PackageDependencyIssue{Kind: DevDependencyInProduction, Package: owner, Dependency: name, Files: productionFiles})
+				}
+			}
+		}
+
+		for _, dependencies := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+			for name := range dependencies {
+				if graph.workspacePackages[name] != nil || packages[name] != nil {
+					continue
+				}
+				// TypeScript includes every @types package by default, so packages like
+				// @types/node and @types/jest are used without being imported
+				if _, ok := typedPackageName(name); ok {
+					continue
+				}
+				// the automatic JSX runtime imports react/jsx-runtime when JSX is compiled
+				if name == jsxImportSource && hasJSX.Has(manifestPath) {
+					continue
+				}
+				issues = append(issues, PackageDependencyIssue{Kind: UnusedDependency, Package: owner, Dependency: name})
+			}
+		}
+	}
+
+	slices.SortFunc(issues, comparePackageDependencyIssues)
+	return issues
+}
+
+// The package JSX is compiled to imports of e.g. "react/jsx-runtime"
+const jsxImportSource = "react"
+
+func addImportingFile(imported map[string]utils.Set[string], packageName, file string) {
+	if imported[packageName] == nil {
+		imported[packageName] = make(utils.Set[string], 0)
+	}
+	imported[packageName].Add(file)
+}
+
+// Returns the closest package.json in the file's directory or one of its parents
+func (graph *SingleThreadedGraphParser) nearestManifest(file string) string {
+	for directory := filepath.Dir(file); ; directory = filepath.Dir(directory) {
+		manifestPath := filepath.Join(directory, "package.json")
+		if graph.files.Has(manifestPath) {
+			return manifestPath
+		}
+		if directory == "." || directory == "/" {
+			return ""
+		}
+	}
+}
+
+// Whether a package is declared in any of the package.json's dependency fields. When the
+// package is only imported for types, its DefinitelyTyped package e.g. "@types/react" for
+// "react" also declares it. Runtime imports need the package itself.
+func (manifest packageManifest) declares(name string, typeOnly bool) bool {
+	for _, dependencies := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		if _, ok := dependencies[name]; ok {
+			return true
+		}
+		if _, ok := dependencies[definitelyTypedName(name)]; ok && typeOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether a package is only declared in devDependencies
+func (manifest packageManifest) isDevDependency(name string) bool {
+	for _, dependencies := range []map[string]string{manifest.Dependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		if _, ok := dependencies[name]; ok {
+			return false
+		}
+	}
+	_, ok := manifest.DevDependencies[name]
+	return ok
+}
+
+// Returns the DefinitelyTyped package for a package e.g. "@types/react" for "react"
+// and "@types/babel__core" for "@babel/core"
+func definitelyTypedName(name string) string {
+	if scope, pkg, ok := strings.Cut(strings.TrimPrefix(name, "@"), "/"); ok {
+		return "@types/" + scope + "__" + pkg
+	}
+	return "@types/" + name
+}
+
+// Returns the package a DefinitelyTyped package describes
+func typedPackageName(name string) (string, bool) {
+	typed, ok := strings.CutPrefix(name, "@types/")
+	if !ok {
+		return "", false
+	}
+	if scope, pkg, ok := strings.Cut(typed, "__"); ok {
+		return "@" + scope + "/" + pkg, true
+	}
+	return typed, true
+}
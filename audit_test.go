@@ -0,0 +1,41 @@
+package dependor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	parser, _ := parseFixture(t, "test_workspaces/npm")
+
+	// design-system uses react through the JSX in its .tsx files even though it never
+	// imports it, and web's @types/jest and @types/node are used without being imported
+	expected := []PackageDependencyIssue{
+		{Kind: UndeclaredDependency, Package: "web", Dependency: "@org/ignored", Files: []string{"apps/web/src/app.tsx"}},
+		{Kind: UndeclaredDependency, Package: "web", Dependency: "@org/tools", Files: []string{"apps/web/src/app.tsx"}},
+		// the test file is allowed to import date-fns and vitest, and debounce.ts only imports its types
+		{Kind: DevDependencyInProduction, Package: "web", Dependency: "date-fns", Files: []string{"apps/web/src/app.tsx"}},
+		// @types/lodash only declares lodash for type imports. express is only imported for types.
+		{Kind: UndeclaredDependency, Package: "web", Dependency: "lodash", Files: []string{"apps/web/src/debounce.ts"}},
+	}
+	issues := parser.Audit()
+	if !reflect.DeepEqual(issues, expected) {
+		t.Errorf("Expected issues %+v. Got %+v", expected, issues)
+	}
+}
+
+func TestDefinitelyTypedName(t *testing.T) {
+	tests := map[string]string{
+		"react":       "@types/react",
+		"@babel/core": "@types/babel__core",
+	}
+	for name, expected := range tests {
+		typed := definitelyTypedName(name)
+		if typed != expected {
+			t.Errorf("Expected %q to be typed by %q. Got %q", name, expected, typed)
+		}
+		if described, ok := typedPackageName(typed); !ok || described != name {
+			t.Errorf("Expected %q to describe %q. Got %q", typed, name, described)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/stilt0n/dependor"
+)
+
+type affectedPackage struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Prints the workspace packages affected by changed files as JSON and returns the exit code
+func printAffected(graphParser *dependor.SingleThreadedGraphParser, args []string) int {
+	affectedFlags := flag.NewFlagSet("affected", flag.ExitOnError)
+	baseFlag := affectedFlags.String("base", "main", "The git revision whose merge base with HEAD is diffed against when changed files aren't piped to stdin")
+	affectedFlags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: dependor affected [-base revision]")
+		fmt.Fprintln(os.Stderr, "\nPrints the workspace packages affected by changed files as JSON. Changed files are read from stdin when it is piped. Otherwise, or when nothing is piped, they are the files changed since HEAD branched from the base revision, including uncommitted changes.")
+		affectedFlags.PrintDefaults()
+	}
+	affectedFlags.Parse(args)
+
+	changedFiles, err := readChangedFiles(*baseFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read changed files: %s\n", err)
+		return 1
+	}
+
+	affected := make([]affectedPackage, 0)
+	for _, pkg := range graphParser.AffectedPackages(changedFiles) {
+		affected = append(affected, affectedPackage{Name: pkg.Name, Path: pkg.Directory})
+	}
+	jsonOutput, err := json.Marshal(affected)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "An error occurred when stringifying the output:\n%s\n", err)
+		return 1
+	}
+	fmt.Println(string(jsonOutput))
+	return 0
+}
+
+func readChangedFiles(base string) ([]string, error) {
+	stat, err := os.Stdin.Stat()
+	if err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+		lines, err := readLines(os.Stdin)
+		// CI often runs commands with stdin attached to /dev/null or an empty pipe
+		if err != nil || len(lines) > 0 {
+			return lines, err
+		}
+	}
+	// diffing the working tree against the merge base includes committed branch work as well
+	// as uncommitted changes, like `git diff base...HEAD` followed by `git diff HEAD`
+	mergeBase, err := exec.Command("git", "merge-base", base, "HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not find the merge base of %q and HEAD: %w", base, err)
+	}
+	// --relative makes paths relative to the project root when it isn't the repo root
+	output, err := exec.Command("git", "diff", "--name-only", "--relative", strings.TrimSpace(string(mergeBase))).Output()
+	if err != nil {
+		return nil, err
+	}
+	return readLines(strings.NewReader(string(output)))
+}
+
+func readLines(reader io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints every issue found by the dependency audit and returns the exit code
+func printAudit(graphParser *dependor.SingleThreadedGraphParser) int {
+	issues := graphParser.Audit()
+	for _, issue := range issues {
+		if len(issue.Files) > 0 {
+			fmt.Printf("%s: %s %q (imported by %s)\n", issue.Package, issue.Kind, issue.Dependency, strings.Join(issue.Files, ", "))
+			continue
+		}
+		fmt.Printf("%s: %s %q\n", issue.Package, issue.Kind, issue.Dependency)
+	}
+	if len(issues) > 0 {
+		fmt.Printf("\nFound %d dependency issues\n", len(issues))
+		return 1
+	}
+	return 0
+}
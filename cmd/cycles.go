@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints every import cycle and returns the exit code
+func printCycles(graphParser *dependor.SingleThreadedGraphParser, args []string) int {
+	cyclesFlags := flag.NewFlagSet("cycles", flag.ExitOnError)
+	ignoreTypeOnlyFlag := cyclesFlags.Bool("ignore-type-only", false, "Ignore imports that only import types")
+	ignoreDynamicFlag := cyclesFlags.Bool("ignore-dynamic", false, "Ignore imports that only use import()")
+	cyclesFlags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: dependor cycles [-ignore-type-only] [-ignore-dynamic]")
+		fmt.Fprintln(os.Stderr, "\nPrints a shortest cycle for every group of files that import each other.")
+		cyclesFlags.PrintDefaults()
+	}
+	cyclesFlags.Parse(args)
+
+	graph := graphParser.EdgesMatching(func(edge dependor.Edge) bool {
+		return !(*ignoreTypeOnlyFlag && edge.TypeOnly) && !(*ignoreDynamicFlag && edge.Dynamic)
+	})
+	cycles := graph.Cycles()
+	for _, cycle := range cycles {
+		fmt.Printf("%s (%d files)\n", cycle, len(cycle.Nodes))
+	}
+	if len(cycles) > 0 {
+		fmt.Printf("\nFound %d cycles\n", len(cycles))
+		return 1
+	}
+	return 0
+}
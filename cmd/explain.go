@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints the resolution trace for a file's import and returns the exit code
+func printExplanation(graphParser *dependor.SingleThreadedGraphParser, args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: dependor explain <file> <specifier>")
+		return 2
+	}
+	trace, err := graphParser.Explain(args[0], args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not explain import: %s\n", err)
+		return 1
+	}
+	fmt.Println(trace)
+	return 0
+}
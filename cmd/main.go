@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/stilt0n/dependor"
 )
@@ -10,6 +11,7 @@ import (
 func main() {
 	var writeFlag = flag.Bool("write", false, "Write output to dependor-output.json file")
 	var prettyPrintFlag = flag.Bool("pretty", false, "Pretty print output to stdout")
+	flag.Usage = usage
 	flag.Parse()
 
 	graphParser := dependor.NewSync(".")
@@ -19,6 +21,28 @@ func main() {
 		return
 	}
 
+	switch command := flag.Arg(0); command {
+	case "":
+	case "unresolved":
+		os.Exit(printUnresolved(graphParser))
+	case "explain":
+		os.Exit(printExplanation(graphParser, flag.Args()[1:]))
+	case "order":
+		os.Exit(printOrder(graphParser, graph, flag.Args()[1:]))
+	case "affected":
+		os.Exit(printAffected(graphParser, flag.Args()[1:]))
+	case "audit":
+		os.Exit(printAudit(graphParser))
+	case "cycles":
+		os.Exit(printCycles(graphParser, flag.Args()[1:]))
+	case "why":
+		os.Exit(printWhy(graphParser, graph, flag.Args()[1:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n", command)
+		usage()
+		os.Exit(2)
+	}
+
 	if *writeFlag {
 		graph.WriteToJSONFile()
 		return
@@ -36,6 +60,21 @@ func main() {
 	fmt.Println(jsonOutput)
 }
 
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: dependor [flags] [command]")
+	fmt.Fprintln(os.Stderr, "\nWithout a command the dependency graph is printed as JSON.")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	fmt.Fprintln(os.Stderr, "  unresolved                  list local imports that don't match a file and exit 1 if there are any")
+	fmt.Fprintln(os.Stderr, "  explain <file> <specifier>  show each step taken to resolve a specifier imported by file")
+	fmt.Fprintln(os.Stderr, "  order [-files] [node...]    print workspace packages in dependency order grouped into levels that can build in parallel")
+	fmt.Fprintln(os.Stderr, "  affected [-base revision]   print the workspace packages affected by changed files as JSON")
+	fmt.Fprintln(os.Stderr, "  audit                       list packages missing from or unused in package.json and exit 1 if there are any")
+	fmt.Fprintln(os.Stderr, "  cycles [flags]              print import cycles and exit 1 if there are any")
+	fmt.Fprintln(os.Stderr, "  why [-limit n] <from> <to>  print the chain of imports that makes from depend on to")
+	fmt.Fprintln(os.Stderr, "\nFlags:")
+	flag.PrintDefaults()
+}
+
 func printGraph(graph dependor.DependencyGraph) {
 	for node, edges := range graph {
 		fmt.Printf("%q: {", node)
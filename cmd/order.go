@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints nodes in dependency order grouped into levels that can be built in parallel
+// and returns the exit code
+func printOrder(graphParser *dependor.SingleThreadedGraphParser, graph dependor.DependencyGraph, args []string) int {
+	orderFlags := flag.NewFlagSet("order", flag.ExitOnError)
+	filesFlag := orderFlags.Bool("files", false, "Order files instead of workspace packages")
+	orderFlags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: dependor order [-files] [node...]")
+		fmt.Fprintln(os.Stderr, "\nPrints workspace packages in dependency order. When nodes are given only they and their dependencies are ordered.")
+		orderFlags.PrintDefaults()
+	}
+	orderFlags.Parse(args)
+
+	if !*filesFlag {
+		graph = graphParser.PackageGraph().DependencyGraph()
+	}
+	if nodes := orderFlags.Args(); len(nodes) > 0 {
+		graph = withDependencies(graph, nodes)
+	}
+
+	levels, err := graph.TopologicalLevels()
+	var cycleError *dependor.CycleError
+	if errors.As(err, &cycleError) {
+		fmt.Fprintf(os.Stderr, "Could not order nodes because of a %s\n", err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not order nodes: %s\n", err)
+		return 1
+	}
+	for i, level := range levels {
+		fmt.Printf("%d: %s\n", i, strings.Join(level, " "))
+	}
+	return 0
+}
+
+// Returns the part of the graph made up of the nodes and everything they depend on
+func withDependencies(graph dependor.DependencyGraph, nodes []string) dependor.DependencyGraph {
+	subgraph := make(dependor.DependencyGraph, 0)
+	for _, node := range nodes {
+		graph.Traverse(node, func(visited string) {
+			subgraph[visited] = graph[visited]
+		})
+	}
+	return subgraph
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints every unresolved import and returns the exit code
+func printUnresolved(graphParser *dependor.SingleThreadedGraphParser) int {
+	unresolved := graphParser.UnresolvedImports()
+	for _, unresolvedImport := range unresolved {
+		fmt.Println(unresolvedImport)
+	}
+	if len(unresolved) > 0 {
+		fmt.Printf("\nFound %d unresolved imports\n", len(unresolved))
+		return 1
+	}
+	return 0
+}
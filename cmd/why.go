@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/stilt0n/dependor"
+)
+
+// Prints the chains of imports that make one node depend on another and returns the exit code
+func printWhy(graphParser *dependor.SingleThreadedGraphParser, graph dependor.DependencyGraph, args []string) int {
+	whyFlags := flag.NewFlagSet("why", flag.ExitOnError)
+	limitFlag := whyFlags.Int("limit", 1, "The number of import chains to print. Use 0 to print every chain")
+	whyFlags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: dependor why [-limit n] <from> <to>")
+		fmt.Fprintln(os.Stderr, "\nPrints the shortest chain of imports from one file to another file or package.")
+		whyFlags.PrintDefaults()
+	}
+	whyFlags.Parse(args)
+	if whyFlags.NArg() != 2 {
+		whyFlags.Usage()
+		return 2
+	}
+	from, to := whyFlags.Arg(0), whyFlags.Arg(1)
+	// a node trivially depends on itself, so there are no imports to print
+	if from == to {
+		fmt.Fprintf(os.Stderr, "<from> and <to> are both %q. Pass two different nodes.\n", from)
+		return 2
+	}
+
+	var paths [][]string
+	for _, target := range targetNodes(graphParser, graph, to) {
+		if *limitFlag == 1 {
+			if path := graph.ShortestPath(from, target); path != nil && (len(paths) == 0 || len(path) < len(paths[0])) {
+				paths = [][]string{path}
+			}
+			continue
+		}
+		paths = append(paths, graph.AllPaths(from, target, *limitFlag)...)
+	}
+	if len(paths) == 0 {
+		fmt.Fprintf(os.Stderr, "%q does not depend on %q\n", from, to)
+		return 1
+	}
+	slices.SortStableFunc(paths, func(a, b []string) int {
+		return len(a) - len(b)
+	})
+	if *limitFlag > 1 && len(paths) > *limitFlag {
+		paths = paths[:*limitFlag]
+	}
+
+	for i, path := range paths {
+		if i > 0 {
+			fmt.Println()
+		}
+		for j := 0; j < len(path)-1; j++ {
+			fmt.Println(describeEdge(graphParser, path[j], path[j+1]))
+		}
+	}
+	return 0
+}
+
+// Returns the nodes that `to` refers to. This is the node itself, or every import of a
+// package e.g. "lodash/fp" and "lodash" for "lodash".
+func targetNodes(graphParser *dependor.SingleThreadedGraphParser, graph dependor.DependencyGraph, to string) []string {
+	if _, ok := graph[to]; ok {
+		return []string{to}
+	}
+	targets := []string{to}
+	for file := range graph {
+		for _, edge := range graphParser.Edges(file) {
+			if edge.Package == to && edge.To != to && !slices.Contains(targets, edge.To) {
+				targets = append(targets, edge.To)
+			}
+		}
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// Describes the import that makes `from` depend on `to` e.g. `src/app.tsx:3:20: imports "./chart" (src/chart.tsx)`
+func describeEdge(graphParser *dependor.SingleThreadedGraphParser, from, to string) string {
+	for _, edge := range graphParser.Edges(from) {
+		if edge.To != to {
+			continue
+		}
+		if edge.Line == 0 {
+			return fmt.Sprintf("%s: imports %s", from, to)
+		}
+		if edge.Specifier == to {
+			return fmt.Sprintf("%s:%d:%d: imports %q", from, edge.Line, edge.Column, edge.Specifier)
+		}
+		return fmt.Sprintf("%s:%d:%d: imports %q (%s)", from, edge.Line, edge.Column, edge.Specifier, to)
+	}
+	return fmt.Sprintf("%s: imports %s", from, to)
+}
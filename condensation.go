@@ -0,0 +1,217 @@
+package dependor
+
+import (
+	"slices"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// A directed acyclic graph whose nodes are the strongly connected components of a
+// dependency graph. Components are referred to by their index in Components. Depths and
+// reachability are memoized the first time they are needed, so a condensation should be
+// reused for many queries but isn't safe for concurrent use.
+type Condensation struct {
+	// The graph's strongly connected components sorted by their first node
+	Components [][]string
+	// The components each component depends on, sorted without duplicates
+	Edges [][]int
+	// maps each node to the index of its component
+	componentOf map[string]int
+	graph       DependencyGraph
+	// these are computed the first time they are needed
+	depths              []int
+	reversed            DependencyGraph
+	dependentEdges      [][]int
+	reachesDependencies []utils.Set[int]
+	reachedByDependents []utils.Set[int]
+}
+
+// Collapses each strongly connected component into a single node. Unlike the graph itself,
+// the condensation never has cycles so every node can be given a depth and an order.
+func (dg DependencyGraph) Condensation() *Condensation {
+	components := dg.StronglyConnectedComponents()
+	condensation := &Condensation{
+		Components:  components,
+		Edges:       make([][]int, len(components)),
+		componentOf: make(map[string]int, len(dg)),
+		graph:       dg,
+	}
+	for i, component := range components {
+		for _, node := range component {
+			condensation.componentOf[node] = i
+		}
+	}
+	for i, component := range components {
+		edges := make([]int, 0)
+		for _, node := range component {
+			for _, edge := range dg[node] {
+				if target := condensation.componentOf[edge]; target != i {
+					edges = append(edges, target)
+				}
+			}
+		}
+		slices.Sort(edges)
+		condensation.Edges[i] = slices.Compact(edges)
+	}
+	return condensation
+}
+
+// Returns the index of the component a node is in
+func (c *Condensation) Component(node string) (int, bool) {
+	component, ok := c.componentOf[node]
+	return component, ok
+}
+
+// Returns each component's depth, which is the length of the longest chain of dependencies
+// below it. Components without dependencies have a depth of 0.
+func (c *Condensation) Depths() []int {
+	if c.depths != nil {
+		return c.depths
+	}
+	depths := make([]int, len(c.Components))
+	finished := make([]bool, len(c.Components))
+	var depthOf func(component int) int
+	depthOf = func(component int) int {
+		if finished[component] {
+			return depths[component]
+		}
+		for _, edge := range c.Edges[component] {
+			depths[component] = max(depths[component], depthOf(edge)+1)
+		}
+		finished[component] = true
+		return depths[component]
+	}
+	for component := range c.Components {
+		depthOf(component)
+	}
+	c.depths = depths
+	return depths
+}
+
+// Returns the components in an order where every component comes after the components it
+// depends on. Components are ordered by depth and then by their first node.
+func (c *Condensation) TopologicalOrder() []int {
+	depths := c.Depths()
+	order := make([]int, len(c.Components))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return depths[a] - depths[b]
+	})
+	return order
+}
+
+// Returns the longest chain of components where each component depends on the next one.
+// When more than one chain is the longest, the chain with the first components is used.
+func (c *Condensation) CriticalPath() []int {
+	if len(c.Components) == 0 {
+		return []int{}
+	}
+	depths := c.Depths()
+	current := 0
+	for component, depth := range depths {
+		if depth > depths[current] {
+			current = component
+		}
+	}
+	path := []int{current}
+	for depths[current] > 0 {
+		for _, edge := range c.Edges[current] {
+			if depths[edge] == depths[current]-1 {
+				current = edge
+				break
+			}
+		}
+		path = append(path, current)
+	}
+	return path
+}
+
+// Returns every node in an order where each node comes after the nodes it depends on.
+// Nodes in the same cycle depend on each other so they are next to each other in sorted
+// order. Use TopologicalLevels to get an error for cycles instead.
+func (dg DependencyGraph) TopologicalOrder() []string {
+	condensation := dg.Condensation()
+	order := make([]string, 0, len(condensation.componentOf))
+	for _, component := range condensation.TopologicalOrder() {
+		order = append(order, condensation.Components[component]...)
+	}
+	return order
+}
+
+// Returns the depth of every node, which is the length of the longest chain of imports
+// below it. Nodes without dependencies have a depth of 0 and nodes in the same cycle
+// share a depth. This can be used to put every node on a layer.
+func (dg DependencyGraph) Depths() map[string]int {
+	condensation := dg.Condensation()
+	componentDepths := condensation.Depths()
+	depths := make(map[string]int, len(condensation.componentOf))
+	for node, component := range condensation.componentOf {
+		depths[node] = componentDepths[component]
+	}
+	return depths
+}
+
+// Returns the longest chain of imports in the graph, starting with the node that imports
+// the next one and ending with a node that has no dependencies. When the chain passes
+// through a cycle, it includes the shortest path through the cycle's nodes.
+func (dg DependencyGraph) CriticalPath() []string {
+	condensation := dg.Condensation()
+	components := condensation.CriticalPath()
+	if len(components) == 0 {
+		return []string{}
+	}
+
+	var path []string
+	entry := condensation.Components[components[0]][0]
+	for i := range components {
+		if i == len(components)-1 {
+			path = append(path, entry)
+			break
+		}
+		next := components[i+1]
+		within := dg.pathWithin(condensation, entry, func(node string) bool {
+			return slices.ContainsFunc(dg[node], func(edge string) bool {
+				return condensation.componentOf[edge] == next
+			})
+		})
+		path = append(path, within...)
+		exit := within[len(within)-1]
+		for _, edge := range dg.sortedEdges(exit) {
+			if condensation.componentOf[edge] == next {
+				entry = edge
+				break
+			}
+		}
+	}
+	return path
+}
+
+// Finds the shortest path from a node to a node that `isTarget` returns true for without
+// leaving the node's component
+func (dg DependencyGraph) pathWithin(condensation *Condensation, from string, isTarget func(node string) bool) []string {
+	component := condensation.componentOf[from]
+	parents := map[string]string{from: from}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isTarget(current) {
+			path := []string{current}
+			for node := current; node != from; node = parents[node] {
+				path = append(path, parents[node])
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, edge := range dg.sortedEdges(current) {
+			if _, seen := parents[edge]; seen || condensation.componentOf[edge] != component {
+				continue
+			}
+			parents[edge] = current
+			queue = append(queue, edge)
+		}
+	}
+	return []string{from}
+}
@@ -0,0 +1,155 @@
+package dependor
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// A strongly connected component with more than one node, or a node that imports itself.
+// Every node in the component can reach every other node.
+type Cycle struct {
+	// The nodes in the component, sorted
+	Nodes []string
+	// One of the shortest cycles through the component's nodes. The first node is
+	// repeated at the end.
+	Path []string
+}
+
+func (cycle Cycle) String() string {
+	return strings.Join(cycle.Path, " -> ")
+}
+
+// Returns the graph's strongly connected components using Tarjan's algorithm. Every node,
+// including nodes that are only imported, is in exactly one component. Nodes that aren't
+// part of a cycle are components by themselves. Each component is sorted and components
+// are sorted by their first node.
+func (dg DependencyGraph) StronglyConnectedComponents() [][]string {
+	nodes := dg.nodes()
+	indices := make(map[string]int, len(nodes))
+	// the smallest index reachable from each node through nodes on the stack
+	lowLinks := make(map[string]int, len(nodes))
+	onStack := make(map[string]bool, len(nodes))
+	var stack []string
+	components := make([][]string, 0)
+
+	var connect func(node string)
+	connect = func(node string) {
+		indices[node] = len(indices)
+		lowLinks[node] = indices[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, edge := range dg.sortedEdges(node) {
+			if _, visited := indices[edge]; !visited {
+				connect(edge)
+				lowLinks[node] = min(lowLinks[node], lowLinks[edge])
+			} else if onStack[edge] {
+				lowLinks[node] = min(lowLinks[node], indices[edge])
+			}
+		}
+
+		// the node is the root of a component so everything above it on the stack is in it
+		if lowLinks[node] == indices[node] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == node {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := indices[node]; !visited {
+			connect(node)
+		}
+	}
+	slices.SortFunc(components, func(a, b []string) int {
+		return strings.Compare(a[0], b[0])
+	})
+	return components
+}
+
+// Returns every strongly connected component that contains a cycle along with one of its
+// shortest cycles. Cycles are sorted by their first node.
+func (dg DependencyGraph) Cycles() []Cycle {
+	cycles := make([]Cycle, 0)
+	for _, component := range dg.StronglyConnectedComponents() {
+		if len(component) == 1 && !slices.Contains(dg[component[0]], component[0]) {
+			continue
+		}
+		cycles = append(cycles, Cycle{Nodes: component, Path: dg.shortestCycle(component)})
+	}
+	return cycles
+}
+
+// Finds a shortest cycle in a strongly connected component with a breadth-first search
+// from each of its nodes. Edges leaving the component can't be part of a cycle so
+// they are skipped.
+func (dg DependencyGraph) shortestCycle(component []string) []string {
+	inComponent := make(utils.Set[string], len(component))
+	for _, node := range component {
+		inComponent.Add(node)
+	}
+	var shortest []string
+	for _, start := range component {
+		parents := map[string]string{start: ""}
+		queue := []string{start}
+	Search:
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, edge := range dg.sortedEdges(current) {
+				if edge == start {
+					cycle := []string{start}
+					for node := current; node != start; node = parents[node] {
+						cycle = append(cycle, node)
+					}
+					cycle = append(cycle, start)
+					slices.Reverse(cycle)
+					if shortest == nil || len(cycle) < len(shortest) {
+						shortest = cycle
+					}
+					break Search
+				}
+				if _, seen := parents[edge]; seen || !inComponent.Has(edge) {
+					continue
+				}
+				parents[edge] = current
+				queue = append(queue, edge)
+			}
+		}
+	}
+	return shortest
+}
+
+// Returns every node in the graph, including nodes that are only imported, sorted
+func (dg DependencyGraph) nodes() []string {
+	seen := make(map[string]bool, len(dg))
+	nodes := make([]string, 0, len(dg))
+	for node, edges := range dg {
+		for _, n := range append([]string{node}, edges...) {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// Returns a node's edges sorted without duplicates so that results don't depend on import order
+func (dg DependencyGraph) sortedEdges(node string) []string {
+	edges := slices.Clone(dg[node])
+	slices.Sort(edges)
+	return slices.Compact(edges)
+}
@@ -3,8 +3,6 @@ package dependor
 import (
 	"encoding/json"
 	"os"
-
-	"github.com/stilt0n/dependor/internal/utils"
 )
 
 // An adjacency list representation of a projects imports and exports
@@ -50,21 +48,11 @@ func (dg DependencyGraph) ReverseEdges() DependencyGraph {
 }
 
 // Performs a breadth-first traversal of the dependency graph
-// starting from `startingNode` and calls `fn` on each visited node
+// starting from `startingNode` and calls `fn` on each visited node.
+// See TraverseWith for more control over the traversal.
 func (dg DependencyGraph) Traverse(startingNode string, fn func(node string)) {
-	workQueue := utils.NewQueue[string]()
-	seen := make(utils.Set[string], 0)
-	workQueue.Enqueue(startingNode)
-	seen.Add(startingNode)
-	for !workQueue.Empty() {
-		currentNode := workQueue.Dequeue()
-		edges := dg[currentNode]
-		fn(currentNode)
-		for _, edge := range edges {
-			if !seen.Has(edge) {
-				workQueue.Enqueue(edge)
-				seen.Add(edge)
-			}
-		}
-	}
+	dg.TraverseWith(startingNode, TraversalOptions{}, func(visit Visit) VisitResult {
+		fn(visit.Node)
+		return Continue
+	})
 }
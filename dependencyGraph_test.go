@@ -1,6 +1,11 @@
 package dependor
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
 
 func TestReverseEdges(t *testing.T) {
 	testGraph := DependencyGraph{"goose": {"wild", "chase"}}
@@ -42,3 +47,335 @@ func TestTraverse(t *testing.T) {
 		}
 	}
 }
+
+func TestTraverseWith(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"header", "footer"},
+		"header": {"logo", "nav"},
+		"footer": {"nav"},
+		"nav":    {"link"},
+		"logo":   {},
+		"link":   {},
+	}
+	tests := []struct {
+		name     string
+		start    string
+		options  TraversalOptions
+		expected []Visit
+	}{
+		{"breadth-first", "app", TraversalOptions{}, []Visit{
+			{"app", 0, ""}, {"header", 1, "app"}, {"footer", 1, "app"}, {"logo", 2, "header"}, {"nav", 2, "header"}, {"link", 3, "nav"},
+		}},
+		{"depth-first", "app", TraversalOptions{Order: DepthFirst}, []Visit{
+			{"app", 0, ""}, {"header", 1, "app"}, {"logo", 2, "header"}, {"nav", 2, "header"}, {"link", 3, "nav"}, {"footer", 1, "app"},
+		}},
+		{"max depth", "app", TraversalOptions{MaxDepth: 1}, []Visit{
+			{"app", 0, ""}, {"header", 1, "app"}, {"footer", 1, "app"},
+		}},
+		// dependents are visited in sorted order
+		{"dependents", "nav", TraversalOptions{Direction: FollowDependents}, []Visit{
+			{"nav", 0, ""}, {"footer", 1, "nav"}, {"header", 1, "nav"}, {"app", 2, "footer"},
+		}},
+		{"edge filter", "app", TraversalOptions{FollowEdge: func(from, to string) bool { return from != "header" }}, []Visit{
+			{"app", 0, ""}, {"header", 1, "app"}, {"footer", 1, "app"}, {"nav", 2, "footer"}, {"link", 3, "nav"},
+		}},
+		// the filter gets edges in the graph's direction when following dependents
+		{"dependents edge filter", "nav", TraversalOptions{Direction: FollowDependents, FollowEdge: func(from, to string) bool { return from != "header" }}, []Visit{
+			{"nav", 0, ""}, {"footer", 1, "nav"}, {"app", 2, "footer"},
+		}},
+	}
+	for _, test := range tests {
+		var visits []Visit
+		testGraph.TraverseWith(test.start, test.options, func(visit Visit) VisitResult {
+			visits = append(visits, visit)
+			return Continue
+		})
+		if !reflect.DeepEqual(visits, test.expected) {
+			t.Errorf("Expected %s visits %v but received %v\n", test.name, test.expected, visits)
+		}
+	}
+}
+
+func TestTraverseWithDepthFirstMaxDepth(t *testing.T) {
+	// c is first reached through b at depth 2, but it is also imported by a at depth 1.
+	// It is only followed from a so that d, which is at depth 2 from a, isn't cut off.
+	testGraph := DependencyGraph{
+		"a": {"b", "c"},
+		"b": {"c"},
+		"c": {"d"},
+		"d": {"e"},
+	}
+	var visits []Visit
+	testGraph.TraverseWith("a", TraversalOptions{Order: DepthFirst, MaxDepth: 2}, func(visit Visit) VisitResult {
+		visits = append(visits, visit)
+		return Continue
+	})
+	expected := []Visit{{"a", 0, ""}, {"b", 1, "a"}, {"c", 1, "a"}, {"d", 2, "c"}}
+	if !reflect.DeepEqual(visits, expected) {
+		t.Errorf("Expected visits %v but received %v\n", expected, visits)
+	}
+}
+
+func TestTraverseWithSkipAndStop(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"header", "footer"},
+		"header": {"logo", "nav"},
+		"footer": {"nav"},
+		"nav":    {"link"},
+	}
+	for _, order := range []TraversalOrder{BreadthFirst, DepthFirst} {
+		var visited []string
+		testGraph.TraverseWith("app", TraversalOptions{Order: order}, func(visit Visit) VisitResult {
+			visited = append(visited, visit.Node)
+			if visit.Node == "header" {
+				return Skip
+			}
+			return Continue
+		})
+		if slices.Contains(visited, "logo") || !slices.Contains(visited, "nav") {
+			t.Errorf("Expected skipping header to skip logo but not nav, which footer imports. Got %v", visited)
+		}
+
+		visited = nil
+		testGraph.TraverseWith("app", TraversalOptions{Order: order}, func(visit Visit) VisitResult {
+			visited = append(visited, visit.Node)
+			if visit.Node == "nav" {
+				return Stop
+			}
+			return Continue
+		})
+		if visited[len(visited)-1] != "nav" || slices.Contains(visited, "link") {
+			t.Errorf("Expected the traversal to stop at nav. Got %v", visited)
+		}
+	}
+}
+
+func TestTopologicalLevels(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui", "utils", "react", "ui"},
+		"ui":     {"utils", "react"},
+		"utils":  {},
+		"docs":   {"ui"},
+		"config": {},
+	}
+	expected := [][]string{
+		{"config", "react", "utils"},
+		{"ui"},
+		{"app", "docs"},
+	}
+	levels, err := testGraph.TopologicalLevels()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Errorf("Expected levels %v but received %v\n", expected, levels)
+	}
+}
+
+func TestTopologicalLevelsCycle(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui"},
+		"ui":     {"theme"},
+		"theme":  {"tokens"},
+		"tokens": {"ui"},
+		"utils":  {},
+	}
+	_, err := testGraph.TopologicalLevels()
+	var cycleError *CycleError
+	if !errors.As(err, &cycleError) {
+		t.Fatalf("Expected a cycle error. Got: %v\n", err)
+	}
+	expected := []string{"ui", "theme", "tokens", "ui"}
+	if !slices.Equal(cycleError.Cycle, expected) {
+		t.Errorf("Expected cycle %v but received %v\n", expected, cycleError.Cycle)
+	}
+	if err.Error() != "dependency cycle: ui -> theme -> tokens -> ui" {
+		t.Errorf("Received unexpected error message %q\n", err)
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui", "utils"},
+		"ui":     {"theme", "react"},
+		"theme":  {"tokens"},
+		"tokens": {"ui"},
+		"utils":  {"utils"},
+	}
+	expected := [][]string{{"app"}, {"react"}, {"theme", "tokens", "ui"}, {"utils"}}
+	if components := testGraph.StronglyConnectedComponents(); !reflect.DeepEqual(components, expected) {
+		t.Errorf("Expected components %v but received %v\n", expected, components)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	testGraph := DependencyGraph{
+		"a": {"b"},
+		"b": {"c", "d"},
+		"c": {"d"},
+		"d": {"a"},
+		"e": {"e"},
+		"f": {"a"},
+	}
+	expected := []Cycle{
+		// a -> b -> c -> d -> a is also a cycle but it isn't the shortest
+		{Nodes: []string{"a", "b", "c", "d"}, Path: []string{"a", "b", "d", "a"}},
+		{Nodes: []string{"e"}, Path: []string{"e", "e"}},
+	}
+	cycles := testGraph.Cycles()
+	if !reflect.DeepEqual(cycles, expected) {
+		t.Fatalf("Expected cycles %v but received %v\n", expected, cycles)
+	}
+	if cycles[0].String() != "a -> b -> d -> a" {
+		t.Errorf("Received unexpected cycle string %q\n", cycles[0])
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui", "utils"},
+		"ui":     {"theme", "utils"},
+		"theme":  {"tokens"},
+		"tokens": {"ui", "react"},
+		"utils":  {},
+	}
+	condensation := testGraph.Condensation()
+	expectedComponents := [][]string{{"app"}, {"react"}, {"theme", "tokens", "ui"}, {"utils"}}
+	if !reflect.DeepEqual(condensation.Components, expectedComponents) {
+		t.Fatalf("Expected components %v but received %v\n", expectedComponents, condensation.Components)
+	}
+	expectedEdges := [][]int{{2, 3}, {}, {1, 3}, {}}
+	if !reflect.DeepEqual(condensation.Edges, expectedEdges) {
+		t.Errorf("Expected edges %v but received %v\n", expectedEdges, condensation.Edges)
+	}
+	if component, ok := condensation.Component("tokens"); !ok || component != 2 {
+		t.Errorf("Expected tokens to be in component 2. Got %d", component)
+	}
+	if order := condensation.TopologicalOrder(); !slices.Equal(order, []int{1, 3, 2, 0}) {
+		t.Errorf("Expected component order [1 3 2 0] but received %v\n", order)
+	}
+
+	expectedOrder := []string{"react", "utils", "theme", "tokens", "ui", "app"}
+	if order := testGraph.TopologicalOrder(); !slices.Equal(order, expectedOrder) {
+		t.Errorf("Expected order %v but received %v\n", expectedOrder, order)
+	}
+	expectedDepths := map[string]int{"react": 0, "utils": 0, "theme": 1, "tokens": 1, "ui": 1, "app": 2}
+	if depths := testGraph.Depths(); !reflect.DeepEqual(depths, expectedDepths) {
+		t.Errorf("Expected depths %v but received %v\n", expectedDepths, depths)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui", "utils"},
+		"ui":     {"theme", "utils"},
+		"theme":  {"tokens"},
+		"tokens": {"ui", "react"},
+		"utils":  {},
+	}
+	// the path goes through the cycle from where app enters it to where it leaves
+	expected := []string{"app", "ui", "theme", "tokens", "react"}
+	if path := testGraph.CriticalPath(); !slices.Equal(path, expected) {
+		t.Errorf("Expected critical path %v but received %v\n", expected, path)
+	}
+
+	if path := (DependencyGraph{}).CriticalPath(); len(path) != 0 {
+		t.Errorf("Expected an empty graph to have an empty critical path. Got %v", path)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"header", "chart"},
+		"header": {"icons", "utils"},
+		"chart":  {"recharts", "utils"},
+		"icons":  {"recharts"},
+		"utils":  {},
+	}
+	tests := []struct {
+		from     string
+		to       string
+		expected []string
+	}{
+		// app -> header -> icons -> recharts is longer
+		{"app", "recharts", []string{"app", "chart", "recharts"}},
+		// chart and header are both one step from utils so the first in sorted order is used
+		{"app", "utils", []string{"app", "chart", "utils"}},
+		{"app", "app", []string{"app"}},
+		{"utils", "app", nil},
+	}
+	for _, test := range tests {
+		if path := testGraph.ShortestPath(test.from, test.to); !slices.Equal(path, test.expected) {
+			t.Errorf("Expected path from %q to %q to be %v but received %v\n", test.from, test.to, test.expected, path)
+		}
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"header", "chart"},
+		"header": {"icons", "utils"},
+		"chart":  {"recharts", "utils"},
+		"icons":  {"recharts", "header"},
+		"utils":  {},
+	}
+	expected := [][]string{
+		{"app", "chart", "recharts"},
+		{"app", "header", "icons", "recharts"},
+	}
+	if paths := testGraph.AllPaths("app", "recharts", 0); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected paths %v but received %v\n", expected, paths)
+	}
+	// edges are searched in sorted order so the first path found is through chart
+	if paths := testGraph.AllPaths("app", "recharts", 1); !reflect.DeepEqual(paths, expected[:1]) {
+		t.Errorf("Expected limited paths %v but received %v\n", expected[:1], paths)
+	}
+	if paths := testGraph.AllPaths("utils", "app", 0); len(paths) != 0 {
+		t.Errorf("Expected no paths but received %v\n", paths)
+	}
+}
+
+func TestDependenciesAndDependents(t *testing.T) {
+	testGraph := DependencyGraph{
+		"app":    {"ui", "utils"},
+		"ui":     {"theme", "utils"},
+		"theme":  {"tokens"},
+		"tokens": {"ui", "react"},
+		"utils":  {"utils"},
+		"docs":   {"utils"},
+	}
+	condensation := testGraph.Condensation()
+	tests := []struct {
+		nodes      []string
+		transitive bool
+		dependsOn  []string
+		dependents []string
+	}{
+		{[]string{"app"}, false, []string{"ui", "utils"}, []string{}},
+		{[]string{"app"}, true, []string{"react", "theme", "tokens", "ui", "utils"}, []string{}},
+		// nodes in a cycle depend on themselves
+		{[]string{"ui"}, true, []string{"react", "theme", "tokens", "ui", "utils"}, []string{"app", "theme", "tokens", "ui"}},
+		{[]string{"utils"}, true, []string{"utils"}, []string{"app", "docs", "theme", "tokens", "ui", "utils"}},
+		{[]string{"react"}, false, []string{}, []string{"tokens"}},
+		{[]string{"react"}, true, []string{}, []string{"app", "theme", "tokens", "ui"}},
+		{[]string{"docs", "theme"}, false, []string{"tokens", "utils"}, []string{"ui"}},
+		{[]string{"docs", "react"}, true, []string{"utils"}, []string{"app", "theme", "tokens", "ui"}},
+		{[]string{"missing"}, true, []string{}, []string{}},
+	}
+	for _, test := range tests {
+		// the condensation is reused so memoized results are checked too
+		if dependencies := condensation.DependenciesOf(test.nodes, test.transitive); !slices.Equal(dependencies, test.dependsOn) {
+			t.Errorf("Expected %v to have dependencies %v (transitive: %t) but received %v\n", test.nodes, test.dependsOn, test.transitive, dependencies)
+		}
+		if dependents := condensation.DependentsOf(test.nodes, test.transitive); !slices.Equal(dependents, test.dependents) {
+			t.Errorf("Expected %v to have dependents %v (transitive: %t) but received %v\n", test.nodes, test.dependents, test.transitive, dependents)
+		}
+	}
+
+	if dependencies := condensation.Dependencies("theme", false); !slices.Equal(dependencies, []string{"tokens"}) {
+		t.Errorf("Expected theme to depend on tokens. Got %v", dependencies)
+	}
+	if dependents := condensation.Dependents("tokens", true); !slices.Equal(dependents, []string{"app", "theme", "tokens", "ui"}) {
+		t.Errorf("Expected tokens to have dependents [app theme tokens ui]. Got %v", dependents)
+	}
+}
@@ -0,0 +1,90 @@
+package dependor
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// A relative or aliased import that doesn't match any file in the project, or an import
+// of a workspace package subpath that the package doesn't export
+type UnresolvedImport struct {
+	// The file the import is written in
+	File      string
+	Specifier string
+	// The 1-based position of the specifier in File
+	Line   int
+	Column int
+	// The paths that were checked in the order they were tried
+	Candidates []string
+	// Why the import couldn't be resolved when it isn't that no candidate matched a file
+	Reason string
+}
+
+func (unresolved UnresolvedImport) String() string {
+	if unresolved.Reason != "" {
+		return fmt.Sprintf("%s:%d:%d: cannot resolve %q (%s)", unresolved.File, unresolved.Line, unresolved.Column, unresolved.Specifier, unresolved.Reason)
+	}
+	return fmt.Sprintf("%s:%d:%d: cannot resolve %q (tried %s)", unresolved.File, unresolved.Line, unresolved.Column, unresolved.Specifier, strings.Join(unresolved.Candidates, ", "))
+}
+
+func compareUnresolvedImports(a, b UnresolvedImport) int {
+	if a.File != b.File {
+		return cmp.Compare(a.File, b.File)
+	}
+	if a.Line != b.Line {
+		return cmp.Compare(a.Line, b.Line)
+	}
+	return cmp.Compare(a.Column, b.Column)
+}
+
+type DiagnosticKind int
+
+const (
+	// An import only matches a file when ignoring case. This works on case-insensitive
+	// file systems like macOS's but fails on case-sensitive ones like Linux's.
+	CaseMismatch DiagnosticKind = iota
+	// An import matches more than one file e.g. both `foo.ts` and `foo/index.ts`.
+	// Bundlers can be configured to pick a different file than dependor.
+	AmbiguousResolution
+)
+
+func (kind DiagnosticKind) String() string {
+	switch kind {
+	case CaseMismatch:
+		return "case mismatch"
+	case AmbiguousResolution:
+		return "ambiguous resolution"
+	default:
+		return "unknown"
+	}
+}
+
+// A problem with an import that doesn't stop it from being parsed
+type Diagnostic struct {
+	Kind DiagnosticKind
+	// The file the import is written in
+	File      string
+	Specifier string
+	// The 1-based position of the specifier in File
+	Line    int
+	Column  int
+	Message string
+	// The files involved e.g. the real path of a file imported with the wrong case
+	// or every file an ambiguous import matches in resolution order
+	Paths []string
+}
+
+func (diagnostic Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", diagnostic.File, diagnostic.Line, diagnostic.Column, diagnostic.Message)
+}
+
+func compareDiagnostics(a, b Diagnostic) int {
+	if a.File != b.File {
+		return cmp.Compare(a.File, b.File)
+	}
+	if a.Line != b.Line {
+		return cmp.Compare(a.Line, b.Line)
+	}
+	return cmp.Compare(a.Column, b.Column)
+}
@@ -0,0 +1,58 @@
+package dependor
+
+// Describes a single edge in the dependency graph
+type Edge struct {
+	From string
+	To   string
+	// The import specifier as it was written in the importing file
+	Specifier string
+	// The 1-based position of the specifier in the importing file. Zero for edges that
+	// weren't written as an import e.g. files matched by import.meta.glob.
+	Line   int
+	Column int
+	Kind   TargetKind
+	// The normalized package name for builtin, external and workspace package edges
+	// e.g. "lodash/fp" has the package name "lodash"
+	Package string
+	// true when the dependency is only loaded at runtime with import()
+	Dynamic bool
+	// true when the dependency is only imported for types so it isn't loaded at runtime
+	TypeOnly bool
+	// true when the edge was matched from a pattern like import(`./locales/${lang}.json`)
+	// so the file may never actually be imported
+	Approximate bool
+}
+
+// What an edge points to
+type TargetKind int
+
+const (
+	// A file that exists in the project
+	LocalFile TargetKind = iota
+	// A Node.js builtin module like "fs" or "node:path"
+	NodeBuiltin
+	// A package that isn't part of the project e.g. one installed in node_modules
+	ExternalPackage
+	// A package whose package.json is in the project
+	WorkspacePackage
+	// A path that doesn't match any file in the project, or a workspace package subpath
+	// that the package's `exports` doesn't export
+	Unresolved
+)
+
+func (kind TargetKind) String() string {
+	switch kind {
+	case LocalFile:
+		return "local"
+	case NodeBuiltin:
+		return "builtin"
+	case ExternalPackage:
+		return "external"
+	case WorkspacePackage:
+		return "workspace"
+	case Unresolved:
+		return "unresolved"
+	default:
+		return "unknown"
+	}
+}
@@ -8,17 +8,30 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/stilt0n/dependor/internal/config"
 	"github.com/stilt0n/dependor/internal/tokenizer"
 	"github.com/stilt0n/dependor/internal/utils"
 )
 
 type SingleThreadedGraphParser struct {
-	tokens     map[string]*tokenizer.FileToken
-	config     *config.Config
-	edgeList   DependencyGraph
-	middleware []func(filepath string)
+	tokens map[string]*tokenizer.FileToken
+	// every file found during the walk including files that were not tokenized
+	files utils.Set[string]
+	// maps lower case paths to the files that have them
+	filesByLowerCase map[string][]string
+	config           *config.Config
+	// maps module names from `declare module "name"` to the file that declares them
+	ambientModules map[string]string
+	// maps package names to the packages in the project
+	workspacePackages map[string]*Package
+	edgeList          DependencyGraph
+	edgeDetails       map[string][]Edge
+	unresolved        []UnresolvedImport
+	diagnostics       []Diagnostic
+	middleware        []func(filepath string)
 }
 
 // Supports single optional rootPath argument. Uses "." by default.
@@ -39,8 +52,12 @@ func NewSync(rootPath ...string) *SingleThreadedGraphParser {
 	}
 
 	return &SingleThreadedGraphParser{
-		config: cfg,
-		tokens: make(map[string]*tokenizer.FileToken, 0),
+		config:            cfg,
+		tokens:            make(map[string]*tokenizer.FileToken, 0),
+		files:             make(utils.Set[string], 0),
+		filesByLowerCase:  make(map[string][]string, 0),
+		ambientModules:    make(map[string]string, 0),
+		workspacePackages: make(map[string]*Package, 0),
 	}
 }
 
@@ -49,6 +66,9 @@ func (graph *SingleThreadedGraphParser) ParseGraph() (DependencyGraph, error) {
 	if err != nil {
 		return nil, err
 	}
+	graph.findAmbientModules()
+	graph.findWorkspacePackages()
+	graph.expandGlobImports()
 	graph.resolveImportExtensions()
 	graph.finishIndexMaps()
 	graph.parseTokens()
@@ -62,6 +82,99 @@ func (graph *SingleThreadedGraphParser) GetCustomConfig() ([]byte, error) {
 	return graph.config.GetCustomConfig()
 }
 
+// Returns details about each of a file's edges. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) Edges(file string) []Edge {
+	return graph.edgeDetails[file]
+}
+
+// Returns every local import that didn't match a file sorted by file and position.
+// Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) UnresolvedImports() []UnresolvedImport {
+	slices.SortFunc(graph.unresolved, compareUnresolvedImports)
+	return graph.unresolved
+}
+
+// Records each step taken to resolve a specifier imported by a file. Only available
+// after ParseGraph has been called. Returns an error if the file doesn't import the specifier.
+func (graph *SingleThreadedGraphParser) Explain(file, specifier string) (*ResolutionTrace, error) {
+	if graph.edgeList == nil {
+		return nil, errors.New("ParseGraph needs to be called before Explain")
+	}
+	tk, ok := graph.tokens[file]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a parsed file", file)
+	}
+
+	trace := &ResolutionTrace{File: file, Specifier: specifier}
+	importPath := specifier
+	if strings.HasPrefix(specifier, ".") {
+		importPath = filepath.Join(filepath.Dir(file), specifier)
+		trace.add("Relative specifier is %q from the project root", importPath)
+	}
+	resolved, _ := graph.resolveImportPath(importPath, trace)
+	if alternatives := graph.ambiguousAlternatives(specifier, importPath, resolved); alternatives != nil {
+		trace.add("The import is ambiguous because it also matches %s", strings.Join(quoteAll(alternatives[1:]), ", "))
+	}
+	idents, ok := tk.Imports[resolved]
+	if !ok {
+		return nil, fmt.Errorf("%q does not import %q", file, specifier)
+	}
+
+	targets := []string{resolved}
+	if isIndexFile(resolved) {
+		trace.add("%q is an index file so the imported names are looked up in its exports", resolved)
+		targets = graph.resolveIndexImport(resolved, idents, trace)
+	}
+	slices.Sort(targets)
+	for _, target := range targets {
+		kind, _ := graph.classifyTarget(tk, target, specifier)
+		trace.add("Classified %q as %s", target, kind)
+	}
+	trace.Result = targets
+	return trace, nil
+}
+
+// Returns problems found with imports that didn't stop them from being parsed, sorted
+// by file and position. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) Diagnostics() []Diagnostic {
+	slices.SortFunc(graph.diagnostics, compareDiagnostics)
+	return graph.diagnostics
+}
+
+// Returns the project's workspace packages sorted by name. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) WorkspacePackages() []*Package {
+	packages := make([]*Package, 0, len(graph.workspacePackages))
+	for _, pkg := range graph.workspacePackages {
+		packages = append(packages, pkg)
+	}
+	slices.SortFunc(packages, func(a, b *Package) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return packages
+}
+
+// Returns every file's edges to targets of the given kinds. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) EdgesOfKind(kinds ...TargetKind) DependencyGraph {
+	return graph.EdgesMatching(func(edge Edge) bool {
+		return slices.Contains(kinds, edge.Kind)
+	})
+}
+
+// Returns every file's edges that `keep` returns true for e.g. the graph without type-only
+// imports. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) EdgesMatching(keep func(edge Edge) bool) DependencyGraph {
+	filtered := make(DependencyGraph, len(graph.edgeDetails))
+	for file, edges := range graph.edgeDetails {
+		filtered[file] = make([]string, 0)
+		for _, edge := range edges {
+			if keep(edge) {
+				filtered[file] = append(filtered[file], edge.To)
+			}
+		}
+	}
+	return filtered
+}
+
 // adds a callback to be run before parsing each file
 func (graph *SingleThreadedGraphParser) AddMiddleware(callback func(filepath string)) {
 	graph.middleware = append(graph.middleware, callback)
@@ -69,7 +182,7 @@ func (graph *SingleThreadedGraphParser) AddMiddleware(callback func(filepath str
 
 // Walks file tree from root path and populates tokenizedFiles
 func (graph *SingleThreadedGraphParser) walk() error {
-	searchableExtensions := regexp.MustCompile(`(\.js|\.jsx|\.ts|\.tsx)$`)
+	searchableExtensions := regexp.MustCompile(`\.(js|jsx|mjs|cjs|ts|tsx|mts|cts)$`)
 	// walk always starts in the current directory because the graph constructor
 	// will have already changed directories to the correct one
 	err := filepath.WalkDir(".", func(path string, info fs.DirEntry, err error) error {
@@ -83,6 +196,12 @@ func (graph *SingleThreadedGraphParser) walk() error {
 			return filepath.SkipDir
 		}
 
+		if !info.IsDir() {
+			graph.files.Add(path)
+			lowerCasePath := strings.ToLower(path)
+			graph.filesByLowerCase[lowerCasePath] = append(graph.filesByLowerCase[lowerCasePath], path)
+		}
+
 		if searchableExtensions.MatchString(info.Name()) {
 			for _, callback := range graph.middleware {
 				callback(path)
@@ -97,16 +216,53 @@ func (graph *SingleThreadedGraphParser) walk() error {
 
 func (graph *SingleThreadedGraphParser) parseTokens() {
 	graph.edgeList = make(DependencyGraph, len(graph.tokens))
+	graph.edgeDetails = make(map[string][]Edge, len(graph.tokens))
+	graph.unresolved = make([]UnresolvedImport, 0)
 	for _, tk := range graph.tokens {
 		edges := make([]string, 0)
+		details := make([]Edge, 0)
 		for importPath, importIdents := range tk.Imports {
+			targets := []string{importPath}
 			if isIndexFile(importPath) {
-				edges = append(edges, graph.resolveIndexImport(importPath, importIdents)...)
-			} else {
-				edges = append(edges, importPath)
+				targets = graph.resolveIndexImport(importPath, importIdents, nil)
+			}
+			edges = append(edges, targets...)
+			detail := tk.ImportDetails[importPath]
+			if detail == nil {
+				detail = &tokenizer.ImportDetail{}
+			}
+			for _, target := range targets {
+				kind, packageName := graph.classifyTarget(tk, target, detail.Specifier)
+				if kind == Unresolved {
+					unresolved := UnresolvedImport{
+						File:       tk.FilePath,
+						Specifier:  detail.Specifier,
+						Line:       detail.Line,
+						Column:     detail.Column,
+						Candidates: resolutionCandidates(graph.config.ResolutionOrder, target),
+					}
+					if name, subpath, ok := graph.unexportedWorkspaceSubpath(detail.Specifier); ok {
+						unresolved.Candidates = nil
+						unresolved.Reason = fmt.Sprintf("%q is not exported by %q", subpath, name)
+					}
+					graph.unresolved = append(graph.unresolved, unresolved)
+				}
+				details = append(details, Edge{
+					From:        tk.FilePath,
+					To:          target,
+					Specifier:   detail.Specifier,
+					Line:        detail.Line,
+					Column:      detail.Column,
+					Kind:        kind,
+					Package:     packageName,
+					Dynamic:     detail.Dynamic,
+					TypeOnly:    detail.TypeOnly,
+					Approximate: detail.Approximate,
+				})
 			}
 		}
 		graph.edgeList[tk.FilePath] = edges
+		graph.edgeDetails[tk.FilePath] = details
 	}
 }
 
@@ -115,18 +271,34 @@ func (graph *SingleThreadedGraphParser) readImports(filePath string) {
 	if err != nil {
 		return
 	}
+	tk.SetModuleReferenceCalls(graph.config.ModuleReferenceCalls)
 	tokenizedFile := tk.Tokenize()
 	graph.tokens[tokenizedFile.FilePath] = &tokenizedFile
 }
 
 func (graph *SingleThreadedGraphParser) resolveImportExtensions() {
+	graph.diagnostics = make([]Diagnostic, 0)
 	for _, tk := range graph.tokens {
 		updatedImports := make(map[string][]string, 0)
+		updatedDetails := make(map[string]*tokenizer.ImportDetail, 0)
 		for originalPath, idents := range tk.Imports {
-			updatedPath := withExtension(graph.tokens, graph.config, originalPath)
-			updatedImports[updatedPath] = idents
+			updatedPath, caseMismatch := graph.resolveImportPath(originalPath, nil)
+			if caseMismatch != "" {
+				graph.addCaseMismatch(tk, tk.ImportDetails[originalPath], caseMismatch)
+			}
+			specifier := ""
+			if detail := tk.ImportDetails[originalPath]; detail != nil {
+				specifier = detail.Specifier
+			}
+			if alternatives := graph.ambiguousAlternatives(specifier, originalPath, updatedPath); alternatives != nil {
+				graph.addAmbiguousResolution(tk, tk.ImportDetails[originalPath], alternatives)
+			}
+			// different specifiers can resolve to the same file
+			updatedImports[updatedPath] = append(updatedImports[updatedPath], idents...)
+			updatedDetails[updatedPath] = mergeImportDetails(updatedDetails[updatedPath], tk.ImportDetails[originalPath])
 		}
 		tk.Imports = updatedImports
+		tk.ImportDetails = updatedDetails
 
 		if len(tk.ReExports) == 0 {
 			continue
@@ -135,7 +307,7 @@ func (graph *SingleThreadedGraphParser) resolveImportExtensions() {
 		// ReExports aren't needed for withExtension to work so they
 		// can be safely overwritten in-place
 		for i, originalPath := range tk.ReExports {
-			tk.ReExports[i] = withExtension(graph.tokens, graph.config, originalPath)
+			tk.ReExports[i] = withExtension(graph.tokens, graph.config, originalPath, nil)
 		}
 
 		for k, v := range tk.ReExportMap {
@@ -143,25 +315,45 @@ func (graph *SingleThreadedGraphParser) resolveImportExtensions() {
 			// to resolve that export. But to check, we will need the file's path to
 			// be discoverable in the re-export map.
 			if v == "*" {
-				tk.ReExportMap[withExtension(graph.tokens, graph.config, k)] = v
+				tk.ReExportMap[withExtension(graph.tokens, graph.config, k, nil)] = v
 				continue
 			}
-			tk.ReExportMap[k] = withExtension(graph.tokens, graph.config, v)
+			tk.ReExportMap[k] = withExtension(graph.tokens, graph.config, v, nil)
 		}
 	}
 }
 
-func (graph *SingleThreadedGraphParser) resolveIndexImport(pth string, idents []string) []string {
+func (graph *SingleThreadedGraphParser) resolveIndexImport(pth string, idents []string, trace *ResolutionTrace) []string {
+	tk, ok := graph.tokens[pth]
+	if !ok {
+		trace.add("Index file %q was not tokenized so it is imported itself", pth)
+		return []string{pth}
+	}
+	// imports without names, like files matched by import.meta.glob or side effect
+	// imports, depend on the index file itself
+	if len(idents) == 0 {
+		trace.add("No names are imported from index file %q so it is imported itself", pth)
+		return []string{pth}
+	}
 	resolvedPaths := make(utils.Set[string], 0)
 	for _, ident := range idents {
-		if slices.Contains(graph.tokens[pth].Exports, ident) {
+		// namespace imports use everything the index file exports
+		if ident == "*" {
+			trace.add("Index file %q is imported as a namespace so it is imported itself", pth)
+			resolvedPaths.Add(pth)
+			continue
+		}
+		if slices.Contains(tk.Exports, ident) {
+			trace.add("Index file %q exports %q itself", pth, ident)
 			resolvedPaths.Add(pth)
 			continue
 		}
-		resolved, ok := graph.tokens[pth].ReExportMap[ident]
+		resolved, ok := tk.ReExportMap[ident]
 		if !ok {
+			trace.add("Index file %q does not export %q", pth, ident)
 			continue
 		}
+		trace.add("Index file %q re-exports %q from %q", pth, ident, resolved)
 		resolvedPaths.Add(resolved)
 	}
 	return resolvedPaths.Keys()
@@ -191,30 +383,325 @@ func (graph *SingleThreadedGraphParser) finishIndexMaps() {
 	}
 }
 
-// Resolves any aliases and finds the correct file extension for a path
-func withExtension(pathMap map[string]*tokenizer.FileToken, cfg *config.Config, path string) string {
-	path = cfg.ReplaceAliases(path)
-	extensions := []string{
-		".js",
-		".ts",
-		".jsx",
-		".tsx",
-		"/index.js",
-		"/index.ts",
-		"/index.jsx",
-		"/index.tsx",
+// Glob imports and require.context() calls import every matching file, so they are
+// expanded against the walked files and added to the file's imports.
+func (graph *SingleThreadedGraphParser) expandGlobImports() {
+	for _, tk := range graph.tokens {
+		for _, match := range graph.matchGlobs(tk.FilePath, tk.GlobImports) {
+			addImportPath(tk, match, tokenizer.ImportDetail{})
+		}
+		for _, context := range tk.ContextImports {
+			for _, match := range graph.matchContext(tk.FilePath, context) {
+				addImportPath(tk, match, tokenizer.ImportDetail{})
+			}
+		}
+		// Bundlers include every file that matches a dynamic import pattern, but at
+		// runtime only some of them may actually be imported
+		for _, match := range graph.matchGlobs(tk.FilePath, withResolutionSuffixes(graph.config.ResolutionOrder, tk.DynamicImportPatterns)) {
+			addImportPath(tk, match, tokenizer.ImportDetail{Dynamic: true, Approximate: true})
+		}
+	}
+}
+
+// Returns every file matching at least one of the patterns and none of the negated patterns
+func (graph *SingleThreadedGraphParser) matchGlobs(importingFile string, patterns []string) []string {
+	var included, excluded []string
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			excluded = append(excluded, graph.config.ReplaceAliases(negated))
+			continue
+		}
+		included = append(included, graph.config.ReplaceAliases(pattern))
+	}
+
+	var matches []string
+	for _, file := range graph.files.Keys() {
+		// modules don't import themselves
+		if file == importingFile {
+			continue
+		}
+		if matchesAnyGlob(included, file) && !matchesAnyGlob(excluded, file) {
+			matches = append(matches, file)
+		}
+	}
+	return matches
+}
+
+// Like other imports, patterns from import() don't need an extension e.g. import(`./pages/${name}/index`)
+// can import "pages/home/index.ts". Returns the patterns along with each pattern plus each suffix.
+func withResolutionSuffixes(resolutionOrder []string, patterns []string) []string {
+	withSuffixes := slices.Clone(patterns)
+	for _, pattern := range patterns {
+		for _, suffix := range resolutionOrder {
+			withSuffixes = append(withSuffixes, pattern+suffix)
+		}
+	}
+	return withSuffixes
+}
+
+func (graph *SingleThreadedGraphParser) matchContext(importingFile string, context tokenizer.ContextImport) []string {
+	pattern, err := regexp.Compile(context.Pattern)
+	if err != nil {
+		fmt.Printf("WARN: Skipping require.context() in %q because its regular expression is not supported. See error: %s\n", importingFile, err)
+		return nil
+	}
+	directory := graph.config.ReplaceAliases(context.Directory)
+	var matches []string
+	for _, file := range graph.files.Keys() {
+		relativePath, err := filepath.Rel(directory, file)
+		if err != nil || strings.HasPrefix(relativePath, "..") || file == importingFile {
+			continue
+		}
+		if !context.Recursive && strings.Contains(relativePath, "/") {
+			continue
+		}
+		if pattern.MatchString("./" + relativePath) {
+			matches = append(matches, file)
+		}
 	}
+	return matches
+}
 
-	for _, extension := range extensions {
-		if _, ok := pathMap[path+extension]; ok {
-			return path + extension
+func matchesAnyGlob(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := doublestar.Match(pattern, file); matched {
+			return true
 		}
 	}
+	return false
+}
+
+// Adds an import without identifiers if the path isn't already imported
+func addImportPath(tk *tokenizer.FileToken, importPath string, detail tokenizer.ImportDetail) {
+	if _, ok := tk.Imports[importPath]; ok {
+		return
+	}
+	tk.Imports[importPath] = []string{}
+	if tk.ImportDetails == nil {
+		tk.ImportDetails = make(map[string]*tokenizer.ImportDetail, 0)
+	}
+	tk.ImportDetails[importPath] = &detail
+}
 
+// Combines the details of two imports that resolved to the same path. The
+// combined import is only dynamic, type-only or approximate if both imports were.
+func mergeImportDetails(existing, detail *tokenizer.ImportDetail) *tokenizer.ImportDetail {
+	if detail == nil {
+		detail = &tokenizer.ImportDetail{}
+	}
+	if existing == nil {
+		merged := *detail
+		return &merged
+	}
+	existing.Dynamic = existing.Dynamic && detail.Dynamic
+	existing.TypeOnly = existing.TypeOnly && detail.TypeOnly
+	existing.Approximate = existing.Approximate && detail.Approximate
+	return existing
+}
+
+// Ambient modules are only declared by declaration files that are scripts. In a file with a
+// top-level import or export, `declare module "name"` augments the real package instead.
+func (graph *SingleThreadedGraphParser) findAmbientModules() {
+	for _, tk := range graph.tokens {
+		if !strings.HasSuffix(tk.FilePath, ".d.ts") || tk.IsModule {
+			continue
+		}
+		for _, moduleName := range tk.AmbientModules {
+			graph.ambientModules[moduleName] = tk.FilePath
+		}
+	}
+}
+
+// Determines what kind of module an edge points to and, for packages, the package's name
+func (graph *SingleThreadedGraphParser) classifyTarget(tk *tokenizer.FileToken, target, specifier string) (TargetKind, string) {
+	// imports matched from patterns don't have a specifier and always point to files.
+	// Aliases and reference paths are paths even though they look like bare specifiers.
+	_, _, isAliased := graph.config.MatchAlias(specifier)
+	isPath := specifier == "" || isPathSpecifier(specifier) || isAliased || slices.Contains(tk.ReferencePaths, target)
+	if graph.files.Has(target) {
+		// imports of workspace packages are resolved to files in the package
+		if name, ok := packageName(specifier); ok && !isPath && graph.workspacePackages[name] != nil {
+			return WorkspacePackage, name
+		}
+		return LocalFile, ""
+	}
+	if isPath {
+		return Unresolved, ""
+	}
+	if name, ok := nodeBuiltinName(specifier); ok {
+		return NodeBuiltin, name
+	}
+	name, ok := packageName(specifier)
+	if !ok {
+		return Unresolved, ""
+	}
+	if _, ok := graph.workspacePackages[name]; ok {
+		if _, _, unexported := graph.unexportedWorkspaceSubpath(specifier); unexported {
+			return Unresolved, ""
+		}
+		return WorkspacePackage, name
+	}
+	return ExternalPackage, name
+}
+
+// Resolves an import path to a file. Imports that don't match a file can still
+// be declared by a `declare module "name"` block in a declaration file.
+// When the path only matches a file if case is ignored, that file is also returned.
+func (graph *SingleThreadedGraphParser) resolveImportPath(path string, trace *ResolutionTrace) (string, string) {
+	if entry, ok := graph.resolveWorkspaceImport(path, trace); ok {
+		return entry, ""
+	}
+	resolved := withExtension(graph.tokens, graph.config, path, trace)
+	if graph.files.Has(resolved) {
+		trace.add("Found file %q", resolved)
+		return resolved, ""
+	}
+	if declaringFile, ok := graph.resolveAmbientModule(path); ok {
+		trace.add("Matched an ambient module declared in %q", declaringFile)
+		return declaringFile, ""
+	}
+	trace.add("No file or ambient module matched %q", resolved)
+	caseMismatch, ok := graph.matchIgnoringCase(resolved)
+	if !ok {
+		return resolved, ""
+	}
+	trace.add("Found %q when ignoring case", caseMismatch)
+	if graph.config.ResolveCaseMismatches {
+		trace.add("Resolving to %q because resolveCaseMismatches is enabled", caseMismatch)
+		return caseMismatch, caseMismatch
+	}
+	return resolved, caseMismatch
+}
+
+// Returns the first file that matches a resolution candidate for path when case is ignored
+func (graph *SingleThreadedGraphParser) matchIgnoringCase(path string) (string, bool) {
+	for _, candidate := range resolutionCandidates(graph.config.ResolutionOrder, path) {
+		if matches := graph.filesByLowerCase[strings.ToLower(candidate)]; len(matches) > 0 {
+			return slices.Min(matches), true
+		}
+	}
+	return "", false
+}
+
+// Returns every file an import path matches in resolution order. The first file is
+// the one the import resolves to.
+func (graph *SingleThreadedGraphParser) resolutionAlternatives(path string) []string {
+	var alternatives []string
+	for _, candidate := range resolutionCandidates(graph.config.ResolutionOrder, graph.config.ReplaceAliases(path)) {
+		if _, ok := graph.tokens[candidate]; ok {
+			alternatives = append(alternatives, candidate)
+		}
+	}
+	return alternatives
+}
+
+// Returns the files a path or alias import matches when there is more than one, starting
+// with the file it resolved to. Returns nil when the import didn't resolve to one of them,
+// so package, workspace and ambient module imports are never ambiguous.
+func (graph *SingleThreadedGraphParser) ambiguousAlternatives(specifier, path, resolved string) []string {
+	_, _, isAliased := graph.config.MatchAlias(specifier)
+	if !isPathSpecifier(specifier) && !isAliased {
+		return nil
+	}
+	alternatives := graph.resolutionAlternatives(path)
+	if len(alternatives) < 2 || !slices.Contains(alternatives, resolved) {
+		return nil
+	}
+	others := slices.DeleteFunc(alternatives, func(alternative string) bool { return alternative == resolved })
+	return append([]string{resolved}, others...)
+}
+
+func (graph *SingleThreadedGraphParser) addAmbiguousResolution(tk *tokenizer.FileToken, detail *tokenizer.ImportDetail, alternatives []string) {
+	if detail == nil {
+		detail = &tokenizer.ImportDetail{}
+	}
+	graph.diagnostics = append(graph.diagnostics, Diagnostic{
+		Kind:      AmbiguousResolution,
+		File:      tk.FilePath,
+		Specifier: detail.Specifier,
+		Line:      detail.Line,
+		Column:    detail.Column,
+		Message:   fmt.Sprintf("%q matches more than one file: %s. Using %q", detail.Specifier, strings.Join(quoteAll(alternatives), ", "), alternatives[0]),
+		Paths:     alternatives,
+	})
+}
+
+func (graph *SingleThreadedGraphParser) addCaseMismatch(tk *tokenizer.FileToken, detail *tokenizer.ImportDetail, realPath string) {
+	if detail == nil {
+		detail = &tokenizer.ImportDetail{}
+	}
+	graph.diagnostics = append(graph.diagnostics, Diagnostic{
+		Kind:      CaseMismatch,
+		File:      tk.FilePath,
+		Specifier: detail.Specifier,
+		Line:      detail.Line,
+		Column:    detail.Column,
+		Message:   fmt.Sprintf("%q only matches %q when ignoring case", detail.Specifier, realPath),
+		Paths:     []string{realPath},
+	})
+}
+
+// Ambient module names can contain a single `*` wildcard e.g. declare module "*.svg".
+// Like TypeScript, we prefer exact matches and then the pattern with the longest prefix.
+func (graph *SingleThreadedGraphParser) resolveAmbientModule(path string) (string, bool) {
+	if declaringFile, ok := graph.ambientModules[path]; ok {
+		return declaringFile, true
+	}
+	bestMatch, bestPrefixLength := "", -1
+	for moduleName, declaringFile := range graph.ambientModules {
+		prefix, suffix, hasWildcard := strings.Cut(moduleName, "*")
+		if !hasWildcard || len(prefix) <= bestPrefixLength {
+			continue
+		}
+		if len(path) >= len(prefix)+len(suffix) && strings.HasPrefix(path, prefix) && strings.HasSuffix(path, suffix) {
+			bestMatch, bestPrefixLength = declaringFile, len(prefix)
+		}
+	}
+	return bestMatch, bestPrefixLength >= 0
+}
+
+// Resolves any aliases and finds the correct file extension for a path
+func withExtension(pathMap map[string]*tokenizer.FileToken, cfg *config.Config, path string, trace *ResolutionTrace) string {
+	if alias, replacement, ok := cfg.MatchAlias(path); ok {
+		path = cfg.ReplaceAliases(path)
+		trace.add("Matched path alias %q, replacing it with %q: %q", alias, replacement, path)
+	}
+	for _, candidate := range resolutionCandidates(cfg.ResolutionOrder, path) {
+		if _, ok := pathMap[candidate]; ok {
+			trace.add("Trying %q: found", candidate)
+			return candidate
+		}
+		trace.add("Trying %q: not found", candidate)
+	}
 	return path
 }
 
-var indexFilePattern = regexp.MustCompile("index.(js|ts|jsx|tsx)$")
+// TypeScript projects using ESM resolution import files by their compiled
+// extension, so `./util.js` may refer to `util.ts` on disk.
+var sourceExtensions = map[string][]string{
+	".js":  {".ts", ".tsx"},
+	".jsx": {".tsx"},
+	".mjs": {".mts"},
+	".cjs": {".cts"},
+}
+
+// Returns the paths an import path could refer to in the order they should be tried.
+// `resolutionOrder` is the configured list of suffixes to try.
+func resolutionCandidates(resolutionOrder []string, path string) []string {
+	candidates := make([]string, 0, len(resolutionOrder)+3)
+	for _, extension := range resolutionOrder {
+		candidates = append(candidates, path+extension)
+	}
+	candidates = append(candidates, path)
+	extension := filepath.Ext(path)
+	for _, sourceExtension := range sourceExtensions[extension] {
+		candidates = append(candidates, strings.TrimSuffix(path, extension)+sourceExtension)
+	}
+	return candidates
+}
+
+// only files named index are index files, not e.g. reindex.ts
+var indexFilePattern = regexp.MustCompile(`(^|/)index\.(js|ts|jsx|tsx)$`)
 
 func isIndexFile(filePath string) bool {
 	return indexFilePattern.MatchString(filePath)
@@ -1,8 +1,11 @@
 package dependor
 
 import (
+	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/stilt0n/dependor/internal/tokenizer"
 )
 
 func TestParse(t *testing.T) {
@@ -13,7 +16,7 @@ func TestParse(t *testing.T) {
 		"test_tree/src/components/d.jsx":                              {"react", "@remix-run/react", "test_tree/src/components/i/i.jsx", "test_tree/a.js"},
 		"test_tree/src/components/e.tsx":                              {},
 		"test_tree/src/components/i/i.jsx":                            {},
-		"test_tree/src/components/i/not_imported.ts":                  {"test_tree/re-exports/rexa.js", "test_tree/re-exports/rexb.js"},
+		"test_tree/src/components/i/not_imported.ts":                  {"test_tree/re-exports/rexa.js", "test_tree/re-exports/rexb.js", "test_tree/re-exports/rexc.js"},
 		"test_tree/src/components/i/annoying.jsx":                     {"test_tree/src/components/i/i.jsx"},
 		"test_tree/src/components/i/folder/importFromParentFolder.ts": {"test_tree/src/components/i/i.jsx"},
 		"test_tree/src/components/sibling/importFromSibling.js":       {"test_tree/src/components/i/index.js"},
@@ -27,6 +30,56 @@ func TestParse(t *testing.T) {
 		"test_tree/re-exports/rexc.js":                                {},
 		"test_tree/edge-cases.js":                                     {},
 		"test_tree/type-edge-cases.ts":                                {},
+		"test_tree/types/globals.d.ts":                                {},
+		"test_tree/types/consumer.ts":                                 {"test_tree/types/globals.d.ts", "node", "fs"},
+		"test_tree/bundler/routes.ts": {
+			"test_tree/bundler/pages/home.tsx",
+			"test_tree/bundler/pages/about.tsx",
+			// index files matched by globs are imported themselves
+			"test_tree/bundler/routes/settings/index.ts",
+			"test_tree/bundler/icons/arrow.svg",
+			"test_tree/bundler/icons/close.svg",
+			"test_tree/bundler/worker.js",
+		},
+		"test_tree/bundler/pages/home.tsx":           {},
+		"test_tree/bundler/pages/about.tsx":          {},
+		"test_tree/bundler/pages/_draft.tsx":         {},
+		"test_tree/bundler/worker.js":                {},
+		"test_tree/bundler/routes/settings/index.ts": {"test_tree/bundler/routes/settings/panel.ts"},
+		"test_tree/bundler/routes/settings/panel.ts": {},
+		"test_tree/__tests__/client.test.js":         {"test_tree/util/c.js", "test_tree/b.ts", "test_tree/a.js"},
+		"test_tree/esm/main.mts":                     {"test_tree/esm/util.ts", "test_tree/esm/view.tsx", "test_tree/esm/legacy.cts", "test_tree/esm/helper.mjs"},
+		"test_tree/esm/util.ts":                      {},
+		"test_tree/esm/view.tsx":                     {},
+		"test_tree/esm/legacy.cts":                   {},
+		"test_tree/esm/helper.mjs":                   {},
+		"test_tree/kinds.ts":                         {"node:path", "fs/promises", "node:test", "lodash/fp", "@tanstack/react-query-devtools/production", "test_tree/packages/ui/button.tsx", "test_tree/missing", "test_tree/also-missing", "test_tree/a.js"},
+		"test_tree/packages/ui/button.tsx":           {},
+		"test_tree/casing/Button.tsx":                {},
+		"test_tree/casing/header.tsx":                {"test_tree/casing/button"},
+		"test_tree/ambiguous/foo.ts":                 {},
+		"test_tree/ambiguous/foo/index.ts":           {},
+		"test_tree/ambiguous/bar.js":                 {},
+		"test_tree/ambiguous/bar.ts":                 {},
+		"test_tree/ambiguous/consumer.ts":            {"test_tree/ambiguous/foo.ts", "test_tree/ambiguous/bar.js"},
+		"test_tree/cycles/store.ts":                  {"test_tree/cycles/view.ts"},
+		"test_tree/cycles/view.ts":                   {"test_tree/cycles/types.ts", "test_tree/cycles/store.ts"},
+		"test_tree/cycles/types.ts":                  {"test_tree/cycles/view.ts", "test_tree/cycles/store.ts"},
+		"test_tree/augmentation/request.ts":          {"express"},
+		"test_tree/augmentation/vue.d.ts":            {},
+		"test_tree/augmentation/server.ts":           {"express", "vue"},
+		"test_tree/lazy/loader.ts":                   {"test_tree/lazy/widgets/index.ts", "test_tree/lazy/pages/home/index.ts"},
+		"test_tree/lazy/pages/home/index.ts":         {},
+		"test_tree/lazy/widgets/index.ts":            {},
+		"test_tree/lazy/widgets/header.ts":           {},
+		"test_tree/i18n.ts": {
+			"test_tree/locales/en.json",
+			"test_tree/locales/fr.json",
+			"test_tree/bundler/pages/home.tsx",
+			"test_tree/bundler/pages/about.tsx",
+			"test_tree/bundler/pages/_draft.tsx",
+			"test_tree/bundler/worker.js",
+		},
 	}
 
 	dgraph := NewSync()
@@ -85,6 +138,41 @@ func TestMiddleware(t *testing.T) {
 		"test_tree/re-exports/rexc.js",
 		"test_tree/edge-cases.js",
 		"test_tree/type-edge-cases.ts",
+		"test_tree/types/globals.d.ts",
+		"test_tree/types/consumer.ts",
+		"test_tree/bundler/routes.ts",
+		"test_tree/bundler/pages/home.tsx",
+		"test_tree/bundler/pages/about.tsx",
+		"test_tree/bundler/pages/_draft.tsx",
+		"test_tree/bundler/worker.js",
+		"test_tree/bundler/routes/settings/index.ts",
+		"test_tree/bundler/routes/settings/panel.ts",
+		"test_tree/i18n.ts",
+		"test_tree/__tests__/client.test.js",
+		"test_tree/esm/main.mts",
+		"test_tree/esm/util.ts",
+		"test_tree/esm/view.tsx",
+		"test_tree/esm/legacy.cts",
+		"test_tree/esm/helper.mjs",
+		"test_tree/kinds.ts",
+		"test_tree/packages/ui/button.tsx",
+		"test_tree/casing/Button.tsx",
+		"test_tree/casing/header.tsx",
+		"test_tree/ambiguous/foo.ts",
+		"test_tree/ambiguous/foo/index.ts",
+		"test_tree/ambiguous/bar.js",
+		"test_tree/ambiguous/bar.ts",
+		"test_tree/ambiguous/consumer.ts",
+		"test_tree/cycles/store.ts",
+		"test_tree/cycles/view.ts",
+		"test_tree/cycles/types.ts",
+		"test_tree/augmentation/request.ts",
+		"test_tree/augmentation/vue.d.ts",
+		"test_tree/augmentation/server.ts",
+		"test_tree/lazy/loader.ts",
+		"test_tree/lazy/pages/home/index.ts",
+		"test_tree/lazy/widgets/index.ts",
+		"test_tree/lazy/widgets/header.ts",
 	}
 	parser := NewSync()
 	parser.AddMiddleware(middlewareTest)
@@ -100,3 +188,395 @@ func TestMiddleware(t *testing.T) {
 		}
 	}
 }
+
+func TestEdgeDetails(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	expected := map[string]Edge{
+		"test_tree/locales/en.json":          {Dynamic: true, Approximate: true},
+		"test_tree/locales/fr.json":          {Dynamic: true, Approximate: true},
+		"test_tree/bundler/pages/home.tsx":   {Dynamic: true, Approximate: true},
+		"test_tree/bundler/pages/about.tsx":  {Dynamic: true, Approximate: true},
+		"test_tree/bundler/pages/_draft.tsx": {Dynamic: true, Approximate: true},
+		"test_tree/bundler/worker.js":        {Dynamic: true},
+	}
+	edges := parser.Edges("test_tree/i18n.ts")
+	if len(edges) != len(expected) {
+		t.Fatalf("Expected %d edges but received %d", len(expected), len(edges))
+	}
+	for _, edge := range edges {
+		expectedEdge, ok := expected[edge.To]
+		if !ok {
+			t.Errorf("Unexpected edge to %q", edge.To)
+			continue
+		}
+		if edge.From != "test_tree/i18n.ts" || edge.Dynamic != expectedEdge.Dynamic || edge.Approximate != expectedEdge.Approximate {
+			t.Errorf("Received wrong details for edge to %q: %+v", edge.To, edge)
+		}
+	}
+
+	for _, edge := range parser.Edges("test_tree/src/components/f.tsx") {
+		if edge.To == "dynamic_data" && (!edge.Dynamic || edge.Approximate) {
+			t.Errorf("Expected import(\"dynamic_data\") to be dynamic and exact. Got %+v", edge)
+		}
+		if edge.To == "react" && edge.Dynamic {
+			t.Error("Expected static import of react not to be dynamic")
+		}
+	}
+
+	for _, edge := range parser.Edges("test_tree/kinds.ts") {
+		if edge.To == "lodash/fp" && (edge.Line != 4 || edge.Column != 21) {
+			t.Errorf("Expected import of lodash/fp to be at 4:21. Got %d:%d", edge.Line, edge.Column)
+		}
+	}
+	for _, edge := range edges {
+		if edge.Approximate && edge.Line != 0 {
+			t.Errorf("Expected edges matched from patterns not to have a position. Got %+v", edge)
+		}
+	}
+}
+
+func TestTargetKinds(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	expected := map[string]Edge{
+		"node:path":                        {Kind: NodeBuiltin, Package: "path", Specifier: "node:path"},
+		"fs/promises":                      {Kind: NodeBuiltin, Package: "fs", Specifier: "fs/promises"},
+		"node:test":                        {Kind: NodeBuiltin, Package: "test", Specifier: "node:test"},
+		"lodash/fp":                        {Kind: ExternalPackage, Package: "lodash", Specifier: "lodash/fp"},
+		"test_tree/packages/ui/button.tsx": {Kind: WorkspacePackage, Package: "@acme/ui", Specifier: "@acme/ui/button"},
+		"test_tree/missing":                {Kind: Unresolved, Specifier: "./missing"},
+		"test_tree/also-missing":           {Kind: Unresolved, Specifier: "~/also-missing"},
+		"test_tree/a.js":                   {Kind: LocalFile, Specifier: "./a"},
+		"@tanstack/react-query-devtools/production": {
+			Kind:      ExternalPackage,
+			Package:   "@tanstack/react-query-devtools",
+			Specifier: "@tanstack/react-query-devtools/production",
+		},
+	}
+	edges := parser.Edges("test_tree/kinds.ts")
+	if len(edges) != len(expected) {
+		t.Fatalf("Expected %d edges but received %d", len(expected), len(edges))
+	}
+	for _, edge := range edges {
+		expectedEdge, ok := expected[edge.To]
+		if !ok {
+			t.Errorf("Unexpected edge to %q", edge.To)
+			continue
+		}
+		if edge.Kind != expectedEdge.Kind || edge.Package != expectedEdge.Package || edge.Specifier != expectedEdge.Specifier {
+			t.Errorf("Expected edge to %q to be %s %q from %q. Got %s %q from %q", edge.To, expectedEdge.Kind, expectedEdge.Package, expectedEdge.Specifier, edge.Kind, edge.Package, edge.Specifier)
+		}
+	}
+
+	packages := parser.EdgesOfKind(ExternalPackage, WorkspacePackage)
+	expectedPackages := []string{"lodash/fp", "@tanstack/react-query-devtools/production", "test_tree/packages/ui/button.tsx"}
+	slices.Sort(expectedPackages)
+	slices.Sort(packages["test_tree/kinds.ts"])
+	if !slices.Equal(expectedPackages, packages["test_tree/kinds.ts"]) {
+		t.Errorf("Expected package edges %v. Got %v", expectedPackages, packages["test_tree/kinds.ts"])
+	}
+	if len(packages["test_tree/esm/main.mts"]) != 0 {
+		t.Errorf("Expected test_tree/esm/main.mts to have no package edges. Got %v", packages["test_tree/esm/main.mts"])
+	}
+}
+
+func TestDynamicIndexImports(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	// the pattern has no extension and the namespace import doesn't name any exports,
+	// but both still depend on the index files
+	expected := map[string]Edge{
+		"test_tree/lazy/pages/home/index.ts": {Dynamic: true, Approximate: true},
+		"test_tree/lazy/widgets/index.ts":    {},
+	}
+	edges := parser.Edges("test_tree/lazy/loader.ts")
+	if len(edges) != len(expected) {
+		t.Fatalf("Expected %d edges but received %d: %+v", len(expected), len(edges), edges)
+	}
+	for _, edge := range edges {
+		expectedEdge, ok := expected[edge.To]
+		if !ok {
+			t.Errorf("Unexpected edge to %q", edge.To)
+			continue
+		}
+		if edge.Dynamic != expectedEdge.Dynamic || edge.Approximate != expectedEdge.Approximate {
+			t.Errorf("Received wrong details for edge to %q: %+v", edge.To, edge)
+		}
+	}
+}
+
+func TestIsIndexFile(t *testing.T) {
+	tests := map[string]bool{
+		"index.ts":            true,
+		"src/routes/index.js": true,
+		"src/reindex.ts":      false,
+		"src/myindex_ts":      false,
+		"src/index.test.ts":   false,
+	}
+	for filePath, expected := range tests {
+		if isIndexFile(filePath) != expected {
+			t.Errorf("Expected isIndexFile(%q) to be %t", filePath, expected)
+		}
+	}
+}
+
+func TestResolveIndexImportWithoutToken(t *testing.T) {
+	parser := &SingleThreadedGraphParser{tokens: make(map[string]*tokenizer.FileToken, 0)}
+	// index files that weren't tokenized can't be looked into so they are imported themselves
+	if targets := parser.resolveIndexImport("src/broken/index.js", []string{"foo"}, nil); !slices.Equal(targets, []string{"src/broken/index.js"}) {
+		t.Errorf("Expected the index file itself. Got %v", targets)
+	}
+}
+
+func TestModuleAugmentations(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	// augmenting a package in a module doesn't declare it, so imports still point to the package
+	for _, edge := range parser.Edges("test_tree/augmentation/server.ts") {
+		if edge.Kind != ExternalPackage || edge.To != edge.Package {
+			t.Errorf("Expected %q to be an external package. Got %s %q", edge.Specifier, edge.Kind, edge.To)
+		}
+	}
+}
+
+func TestUnresolvedImports(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	expected := []UnresolvedImport{
+		{File: "test_tree/casing/header.tsx", Specifier: "./button", Line: 1, Column: 24},
+		{File: "test_tree/kinds.ts", Specifier: "./missing", Line: 7, Column: 23},
+		{File: "test_tree/kinds.ts", Specifier: "~/also-missing", Line: 8, Column: 23},
+		{File: "test_tree/util/c.js", Specifier: "./fake_url/printFunc", Line: 8, Column: 25},
+	}
+	unresolved := parser.UnresolvedImports()
+	if len(unresolved) != len(expected) {
+		t.Fatalf("Expected %d unresolved imports but received %d: %v", len(expected), len(unresolved), unresolved)
+	}
+	for i, unresolvedImport := range unresolved {
+		expectedImport := expected[i]
+		if unresolvedImport.File != expectedImport.File || unresolvedImport.Specifier != expectedImport.Specifier || unresolvedImport.Line != expectedImport.Line || unresolvedImport.Column != expectedImport.Column {
+			t.Errorf("Expected unresolved import %d to be %v. Got %v", i, expectedImport, unresolvedImport)
+		}
+	}
+
+	candidates := unresolved[2].Candidates
+	if len(candidates) == 0 || candidates[0] != "test_tree/also-missing.js" || !slices.Contains(candidates, "test_tree/also-missing/index.tsx") {
+		t.Errorf("Expected aliased candidates to be tried. Got %v", candidates)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.Explain("test_tree/a.js", "./b"); err == nil {
+		t.Error("Expected an error when Explain is called before ParseGraph")
+	}
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	trace, err := parser.Explain("test_tree/kinds.ts", "~/also-missing")
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	expectedSteps := []string{
+		`Matched path alias "~", replacing it with "test_tree": "test_tree/also-missing"`,
+		`Trying "test_tree/also-missing.js": not found`,
+		`Trying "test_tree/also-missing/index.tsx": not found`,
+		`No file or ambient module matched "test_tree/also-missing"`,
+		`Classified "test_tree/also-missing" as unresolved`,
+	}
+	for _, step := range expectedSteps {
+		if !slices.Contains(trace.Steps, step) {
+			t.Errorf("Expected trace to contain step %q. Got:\n%s", step, trace)
+		}
+	}
+
+	trace, err = parser.Explain("test_tree/src/components/d.jsx", "./i")
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	if !slices.Equal(trace.Result, []string{"test_tree/src/components/i/i.jsx"}) {
+		t.Errorf("Expected index import to resolve to test_tree/src/components/i/i.jsx. Got %v", trace.Result)
+	}
+	if !slices.Contains(trace.Steps, `Index file "test_tree/src/components/i/index.js" re-exports "IComponent" from "test_tree/src/components/i/i.jsx"`) {
+		t.Errorf("Expected trace to include the index file lookup. Got:\n%s", trace)
+	}
+
+	if _, err := parser.Explain("test_tree/a.js", "./not-imported"); err == nil {
+		t.Error("Expected an error for a specifier the file doesn't import")
+	}
+}
+
+func TestCaseMismatches(t *testing.T) {
+	parser := NewSync()
+	graph, err := parser.ParseGraph()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	expected := Diagnostic{
+		Kind:      CaseMismatch,
+		File:      "test_tree/casing/header.tsx",
+		Specifier: "./button",
+		Line:      1,
+		Column:    24,
+		Message:   `"./button" only matches "test_tree/casing/Button.tsx" when ignoring case`,
+		Paths:     []string{"test_tree/casing/Button.tsx"},
+	}
+	diagnostics := diagnosticsOfKind(parser.Diagnostics(), CaseMismatch)
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic but received %d: %v", len(diagnostics), diagnostics)
+	}
+	if !reflect.DeepEqual(diagnostics[0], expected) {
+		t.Errorf("Expected diagnostic %+v. Got %+v", expected, diagnostics[0])
+	}
+	if !slices.Equal(graph["test_tree/casing/header.tsx"], []string{"test_tree/casing/button"}) {
+		t.Errorf("Expected the import not to be resolved by default. Got %v", graph["test_tree/casing/header.tsx"])
+	}
+
+	parser = NewSync()
+	parser.config.ResolveCaseMismatches = true
+	graph, err = parser.ParseGraph()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	if !slices.Equal(graph["test_tree/casing/header.tsx"], []string{"test_tree/casing/Button.tsx"}) {
+		t.Errorf("Expected the import to resolve to the real path. Got %v", graph["test_tree/casing/header.tsx"])
+	}
+	if len(diagnosticsOfKind(parser.Diagnostics(), CaseMismatch)) != 1 {
+		t.Errorf("Expected case mismatches to be reported even when they are resolved. Got %v", parser.Diagnostics())
+	}
+	for _, unresolved := range parser.UnresolvedImports() {
+		if unresolved.File == "test_tree/casing/header.tsx" {
+			t.Errorf("Expected resolved case mismatch not to be unresolved. Got %v", unresolved)
+		}
+	}
+}
+
+func TestAmbiguousResolution(t *testing.T) {
+	parser := NewSync()
+	graph, err := parser.ParseGraph()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	expected := []Diagnostic{
+		{
+			Kind:      AmbiguousResolution,
+			File:      "test_tree/ambiguous/consumer.ts",
+			Specifier: "./foo",
+			Line:      1,
+			Column:    21,
+			Message:   `"./foo" matches more than one file: "test_tree/ambiguous/foo.ts", "test_tree/ambiguous/foo/index.ts". Using "test_tree/ambiguous/foo.ts"`,
+			Paths:     []string{"test_tree/ambiguous/foo.ts", "test_tree/ambiguous/foo/index.ts"},
+		},
+		{
+			Kind:      AmbiguousResolution,
+			File:      "test_tree/ambiguous/consumer.ts",
+			Specifier: "./bar",
+			Line:      2,
+			Column:    21,
+			Message:   `"./bar" matches more than one file: "test_tree/ambiguous/bar.js", "test_tree/ambiguous/bar.ts". Using "test_tree/ambiguous/bar.js"`,
+			Paths:     []string{"test_tree/ambiguous/bar.js", "test_tree/ambiguous/bar.ts"},
+		},
+	}
+	diagnostics := diagnosticsOfKind(parser.Diagnostics(), AmbiguousResolution)
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Expected diagnostics %+v. Got %+v", expected, diagnostics)
+	}
+
+	parser = NewSync()
+	parser.config.ResolutionOrder = []string{".ts", ".tsx", "/index.ts", ".js", ".jsx"}
+	graph, err = parser.ParseGraph()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	edges := graph["test_tree/ambiguous/consumer.ts"]
+	slices.Sort(edges)
+	if !slices.Equal(edges, []string{"test_tree/ambiguous/bar.ts", "test_tree/ambiguous/foo.ts"}) {
+		t.Errorf("Expected the configured resolution order to be used. Got %v", edges)
+	}
+
+	// only path imports that resolved to one of the matching files are ambiguous
+	if alternatives := parser.ambiguousAlternatives("ambiguous/foo", "test_tree/ambiguous/foo", "test_tree/ambiguous/foo.ts"); alternatives != nil {
+		t.Errorf("Expected a bare specifier not to be ambiguous. Got %v", alternatives)
+	}
+	if alternatives := parser.ambiguousAlternatives("./foo", "test_tree/ambiguous/foo", "test_tree/types/globals.d.ts"); alternatives != nil {
+		t.Errorf("Expected an import that didn't resolve to a matching file not to be ambiguous. Got %v", alternatives)
+	}
+	alternatives := parser.ambiguousAlternatives("./foo", "test_tree/ambiguous/foo", "test_tree/ambiguous/foo/index.ts")
+	if !slices.Equal(alternatives, []string{"test_tree/ambiguous/foo/index.ts", "test_tree/ambiguous/foo.ts"}) {
+		t.Errorf("Expected the resolved file to be listed first. Got %v", alternatives)
+	}
+}
+
+func TestTypeOnlyAndDynamicCycles(t *testing.T) {
+	parser := NewSync()
+	if _, err := parser.ParseGraph(); err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+
+	for _, edge := range parser.Edges("test_tree/cycles/view.ts") {
+		if edge.To == "test_tree/cycles/types.ts" && !edge.TypeOnly {
+			t.Errorf("Expected %q to be a type-only edge", edge.To)
+		}
+		if edge.To == "test_tree/cycles/store.ts" && (edge.TypeOnly || !edge.Dynamic) {
+			t.Errorf("Expected %q to be a dynamic edge that isn't type-only", edge.To)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		ignoreTypeOnly bool
+		ignoreDynamic  bool
+		expected       []Cycle
+	}{
+		{"all edges", false, false, []Cycle{{
+			Nodes: []string{"test_tree/cycles/store.ts", "test_tree/cycles/types.ts", "test_tree/cycles/view.ts"},
+			Path:  []string{"test_tree/cycles/store.ts", "test_tree/cycles/view.ts", "test_tree/cycles/store.ts"},
+		}}},
+		{"without type-only edges", true, false, []Cycle{{
+			Nodes: []string{"test_tree/cycles/store.ts", "test_tree/cycles/view.ts"},
+			Path:  []string{"test_tree/cycles/store.ts", "test_tree/cycles/view.ts", "test_tree/cycles/store.ts"},
+		}}},
+		{"without dynamic edges", false, true, []Cycle{{
+			Nodes: []string{"test_tree/cycles/store.ts", "test_tree/cycles/types.ts", "test_tree/cycles/view.ts"},
+			Path:  []string{"test_tree/cycles/types.ts", "test_tree/cycles/view.ts", "test_tree/cycles/types.ts"},
+		}}},
+		{"without either", true, true, []Cycle{}},
+	}
+	for _, test := range tests {
+		graph := parser.EdgesMatching(func(edge Edge) bool {
+			return !(test.ignoreTypeOnly && edge.TypeOnly) && !(test.ignoreDynamic && edge.Dynamic)
+		})
+		if cycles := graph.Cycles(); !reflect.DeepEqual(cycles, test.expected) {
+			t.Errorf("Expected cycles %v %v. Got %v", test.name, test.expected, cycles)
+		}
+	}
+}
+
+func diagnosticsOfKind(diagnostics []Diagnostic, kind DiagnosticKind) []Diagnostic {
+	var filtered []Diagnostic
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Kind == kind {
+			filtered = append(filtered, diagnostic)
+		}
+	}
+	return filtered
+}
@@ -12,12 +12,67 @@ import (
 
 const config_file_name = "dependor.json"
 
+// Jest and Vitest functions that reference modules by path
+var defaultModuleReferenceCalls = []string{
+	"jest.mock",
+	"jest.unmock",
+	"jest.doMock",
+	"jest.dontMock",
+	"jest.requireActual",
+	"jest.requireMock",
+	"jest.createMockFromModule",
+	"vi.mock",
+	"vi.unmock",
+	"vi.doMock",
+	"vi.doUnmock",
+	"vi.importActual",
+	"vi.importMock",
+}
+
+// Suffixes tried when resolving an import path in the order they are tried
+var defaultResolutionOrder = []string{
+	".js",
+	".ts",
+	".jsx",
+	".tsx",
+	"/index.js",
+	"/index.ts",
+	"/index.jsx",
+	"/index.tsx",
+}
+
+// Files matching these globs are test or tooling code that can import devDependencies
+var defaultTestFilePatterns = []string{
+	"**/__tests__/**",
+	"**/__mocks__/**",
+	"**/test/**",
+	"**/tests/**",
+	"**/*.test.*",
+	"**/*.spec.*",
+	"**/*.stories.*",
+	"**/*.config.*",
+}
+
 type Config struct {
 	// These patterns should work with go's `filepath.Match` function, which means no recursive directory mathing.
 	// This is a pretty big limitation so I may want to add a glob library like https://github.com/gobwas/glob.
 	IgnorePatterns []string `json:"ignorePatterns"`
 	// This allows you to resolve paths like `'~/components/Foo'` or `'@monorepo/package/dir/file'`
 	PathAliases map[string]string `json:"pathAliases"`
+	// Names of functions whose first argument is a module path e.g. `jest.mock("./foo")`.
+	// Calls to these functions are treated as imports of the path.
+	ModuleReferenceCalls []string `json:"moduleReferenceCalls"`
+	// Resolves imports that only match a file when ignoring case e.g. `./Button` for `button.tsx`.
+	// These imports work on case-insensitive file systems like macOS's but fail on Linux.
+	// Either way, they are reported as diagnostics.
+	ResolveCaseMismatches bool `json:"resolveCaseMismatches"`
+	// The suffixes added to import paths when looking for the file they import, in the order
+	// they are tried. Bundlers can be configured to use a different order e.g. webpack's
+	// `resolve.extensions`, and this should match it.
+	ResolutionOrder []string `json:"resolutionOrder"`
+	// Globs for test and tooling files. The dependency audit allows these files
+	// to import packages that are only in devDependencies.
+	TestFilePatterns []string `json:"testFilePatterns"`
 	// This allows tooling that uses dependor for depency parsing and then uses
 	// the parsed graph for something else to make use of dependor's config
 	// rather than needing to introduce a new config file. This might not always
@@ -35,7 +90,10 @@ func ReadConfig(path ...string) (*Config, error) {
 		readFrom = config_file_name
 	}
 	defaultConfig := &Config{
-		IgnorePatterns: []string{"**/node_modules"},
+		IgnorePatterns:       []string{"**/node_modules"},
+		ModuleReferenceCalls: defaultModuleReferenceCalls,
+		ResolutionOrder:      defaultResolutionOrder,
+		TestFilePatterns:     defaultTestFilePatterns,
 	}
 	// By default we assume config is located in the same directory ReadConfig is called from
 	// But ReadConfig supports an optional path argument which allows you to read a config
@@ -63,6 +121,20 @@ func ReadConfig(path ...string) (*Config, error) {
 	}
 	delete(config.CustomConfig, "ignorePatterns")
 	delete(config.CustomConfig, "pathAliases")
+	delete(config.CustomConfig, "moduleReferenceCalls")
+	delete(config.CustomConfig, "resolveCaseMismatches")
+	delete(config.CustomConfig, "resolutionOrder")
+	delete(config.CustomConfig, "testFilePatterns")
+
+	if config.ModuleReferenceCalls == nil {
+		config.ModuleReferenceCalls = defaultModuleReferenceCalls
+	}
+	if config.ResolutionOrder == nil {
+		config.ResolutionOrder = defaultResolutionOrder
+	}
+	if config.TestFilePatterns == nil {
+		config.TestFilePatterns = defaultTestFilePatterns
+	}
 
 	return &config, nil
 }
@@ -80,16 +152,40 @@ func (cfg *Config) ShouldIgnore(path string) bool {
 	return false
 }
 
-// Replaces the first matching alias found in the config or returns the orginal path
+// Whether a file is test or tooling code rather than production code
+func (cfg *Config) IsTestFile(path string) bool {
+	for _, pattern := range cfg.TestFilePatterns {
+		if matches, _ := doublestar.Match(pattern, path); matches {
+			return true
+		}
+	}
+	return false
+}
+
+// Replaces the matching alias found in the config or returns the orginal path
 // Assumes alias will be at the beginning of the path since that's generally how
 // imports are written in JavaScript
 func (cfg *Config) ReplaceAliases(path string) string {
-	for alias, replacement := range cfg.PathAliases {
-		if strings.HasPrefix(path, alias) {
-			return strings.Replace(path, alias, replacement, 1)
+	alias, replacement, ok := cfg.MatchAlias(path)
+	if !ok {
+		return path
+	}
+	return strings.Replace(path, alias, replacement, 1)
+}
+
+// Returns the alias a path starts with and its replacement. When more than one
+// alias matches, the longest alias is used so that e.g. "~/lib" wins over "~".
+func (cfg *Config) MatchAlias(path string) (string, string, bool) {
+	matched := ""
+	for alias := range cfg.PathAliases {
+		if strings.HasPrefix(path, alias) && len(alias) > len(matched) {
+			matched = alias
 		}
 	}
-	return path
+	if matched == "" {
+		return "", "", false
+	}
+	return matched, cfg.PathAliases[matched], true
 }
 
 // Returns an array of bytes than can be unmarshalled into the expected json type
@@ -51,6 +51,27 @@ func TestReadConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultModuleReferenceCalls(t *testing.T) {
+	cfg, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("got an error when reading config. error: %s\n", err)
+	}
+
+	testSliceMatch(t, cfg.ModuleReferenceCalls, defaultModuleReferenceCalls)
+	if _, ok := cfg.CustomConfig["moduleReferenceCalls"]; ok {
+		t.Error("expected moduleReferenceCalls not to be included in the custom config")
+	}
+}
+
+func TestDefaultResolutionOrder(t *testing.T) {
+	cfg, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("got an error when reading config. error: %s\n", err)
+	}
+
+	testSliceMatch(t, cfg.ResolutionOrder, defaultResolutionOrder)
+}
+
 func TestReplacePath(t *testing.T) {
 	cfg, err := ReadConfig()
 	if err != nil {
@@ -62,6 +83,38 @@ func TestReplacePath(t *testing.T) {
 	}
 }
 
+func TestMatchAlias(t *testing.T) {
+	cfg := &Config{PathAliases: map[string]string{"~": "app", "~/lib": "packages/lib"}}
+
+	alias, replacement, ok := cfg.MatchAlias("~/lib/format")
+	if !ok || alias != "~/lib" || replacement != "packages/lib" {
+		t.Errorf("expected the longest alias to match. got %q %q %t", alias, replacement, ok)
+	}
+	if cfg.ReplaceAliases("~/lib/format") != "packages/lib/format" {
+		t.Errorf("incorrect replacement for '~/lib/format'. got %q", cfg.ReplaceAliases("~/lib/format"))
+	}
+	if _, _, ok := cfg.MatchAlias("react"); ok {
+		t.Error("expected no alias to match 'react'")
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	cfg, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("got an error when reading config. error: %s\n", err)
+	}
+
+	testFiles := []string{"src/__tests__/app.js", "src/app.test.tsx", "lib/util.spec.ts", "test/setup.js", "vite.config.ts"}
+	for _, file := range testFiles {
+		if !cfg.IsTestFile(file) {
+			t.Errorf("expected %q to be a test file", file)
+		}
+	}
+	if cfg.IsTestFile("src/app.tsx") {
+		t.Error("expected src/app.tsx not to be a test file")
+	}
+}
+
 func TestIgnorePath(t *testing.T) {
 	cfg, err := ReadConfig()
 	if err != nil {
@@ -0,0 +1,125 @@
+package tokenizer
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Vite's glob import functions. globEager is deprecated but still shows up in older code.
+var globFunctions = []string{"glob", "globEager"}
+
+// Matches every file in a require.context() directory, which is webpack's default
+const defaultContextPattern = `^\./.*$`
+
+// Reads property accesses that follow a word e.g. `.meta.glob` after `import`
+func (t *Tokenizer) readMemberNames() []string {
+	var names []string
+	for t.peekFrom(t.currentIndex) == '.' {
+		t.skipAllFiller()
+		t.readChar()
+		t.skipAllFiller()
+		name := t.readWord()
+		if name == "" {
+			break
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// import.meta is a meta-property rather than an import, but Vite's import.meta.glob()
+// imports every file matching a glob pattern
+func (t *Tokenizer) readImportMeta() {
+	members := t.readMemberNames()
+	if len(members) != 2 || members[0] != "meta" || !slices.Contains(globFunctions, members[1]) {
+		return
+	}
+	if t.peekFrom(t.currentIndex) != '(' {
+		return
+	}
+	t.skipAllFiller()
+	t.readChar()
+	t.skipAllFiller()
+	// import.meta.glob() accepts a single pattern or an array of patterns
+	inArray := t.char == '['
+	if inArray {
+		t.readChar()
+	}
+	for t.char != 0 {
+		t.skipAllFiller()
+		if !isQuote(t.char) {
+			return
+		}
+		t.globImports = append(t.globImports, t.readGlobPattern())
+		t.skipAllFiller()
+		if !inArray || t.char != ',' {
+			return
+		}
+		t.readChar()
+	}
+}
+
+// Glob patterns can be relative, relative to the project root (starting with `/`)
+// or negated with a leading `!`
+func (t *Tokenizer) readGlobPattern() string {
+	pattern := t.readStringLiteral()
+	negated := strings.HasPrefix(pattern, "!")
+	pattern = strings.TrimPrefix(pattern, "!")
+	if isRelativePath(pattern) {
+		pattern = filepath.Join(t.callDir, pattern)
+	} else {
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	if negated {
+		return "!" + pattern
+	}
+	return pattern
+}
+
+// require.resolve() gets the path to a module without running it, which is still a
+// dependency. Webpack's require.context() imports every file in a directory that
+// matches a regular expression.
+func (t *Tokenizer) readRequireMember() {
+	members := t.readMemberNames()
+	if len(members) != 1 || t.peekFrom(t.currentIndex) != '(' {
+		return
+	}
+	switch members[0] {
+	case "resolve":
+		t.readRequire()
+	case "context":
+		t.readRequireContext()
+	}
+}
+
+// Reads the arguments of require.context(directory, recursive, pattern). Only the
+// directory is required.
+func (t *Tokenizer) readRequireContext() {
+	t.skipAllFiller()
+	t.readChar()
+	t.skipAllFiller()
+	if !isQuote(t.char) {
+		return
+	}
+	context := ContextImport{
+		Directory: t.readPathString(),
+		Recursive: true,
+		Pattern:   defaultContextPattern,
+	}
+	t.skipAllFiller()
+	if t.char == ',' {
+		t.readChar()
+		t.skipAllFiller()
+		context.Recursive = t.readWord() != "false"
+		t.skipAllFiller()
+	}
+	if t.char == ',' {
+		t.readChar()
+		t.skipAllFiller()
+		if t.char == '/' {
+			context.Pattern = t.readRegex()
+		}
+	}
+	t.contextImports = append(t.contextImports, context)
+}
@@ -0,0 +1,108 @@
+package tokenizer
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var repeatedWildcards = regexp.MustCompile(`\*+`)
+
+// Reads the specifier of an import() call. Specifiers built with template literals
+// or string concatenation can't be resolved to a single path, but like bundlers we can
+// turn them into a pattern. e.g. import(`./locales/${lang}.json`) or
+// import("./locales/" + lang + ".json") both become the pattern ./locales/*.json.
+// Should be called before the opening parenthesis.
+func (t *Tokenizer) readDynamicImport() {
+	t.skipAllFiller()
+	t.readChar()
+	var specifier strings.Builder
+	isPattern := false
+	t.skipAllFiller()
+	start := t.currentIndex
+Loop:
+	for t.char != 0 {
+		t.skipAllFiller()
+		switch {
+		case t.char == ')' || t.char == ',':
+			break Loop
+		case t.char == '+':
+			t.readChar()
+		case t.char == '`':
+			literal, hasSubstitutions := t.readTemplateSpecifier()
+			specifier.WriteString(literal)
+			isPattern = isPattern || hasSubstitutions
+		case isQuote(t.char):
+			specifier.WriteString(t.readStringLiteral())
+		default:
+			// anything else is an expression we can't know the value of
+			t.skipSpecifierExpression()
+			specifier.WriteString("*")
+			isPattern = true
+		}
+	}
+
+	importPath := specifier.String()
+	if !isPattern {
+		if importPath == "" {
+			return
+		}
+		t.addDynamicImport(t.pathFromSpecifier(importPath, start))
+		return
+	}
+
+	importPath = repeatedWildcards.ReplaceAllString(importPath, "*")
+	// Bundlers can only build a pattern when the specifier starts with something known
+	if strings.HasPrefix(importPath, "*") {
+		return
+	}
+	if isRelativePath(importPath) {
+		importPath = filepath.Join(t.callDir, importPath)
+	}
+	t.dynamicImportPatterns = append(t.dynamicImportPatterns, importPath)
+}
+
+// Reads a template literal, replacing any substitutions with `*`. Substitutions
+// are still tokenized since they are code.
+func (t *Tokenizer) readTemplateSpecifier() (string, bool) {
+	var literal strings.Builder
+	hasSubstitutions := false
+	t.readChar()
+	for t.char != 0 {
+		switch {
+		case t.char == '\\':
+			t.readChar()
+		case t.char == '`':
+			t.readChar()
+			return literal.String(), hasSubstitutions
+		case t.char == '$' && t.peek() == '{':
+			t.readChar()
+			t.tokenizeBlock()
+			literal.WriteString("*")
+			hasSubstitutions = true
+			continue
+		}
+		literal.WriteRune(t.char)
+		t.readChar()
+	}
+	return literal.String(), hasSubstitutions
+}
+
+// Skips part of a specifier expression up to the next `+`, `,` or `)` that isn't nested
+func (t *Tokenizer) skipSpecifierExpression() {
+	depth := 0
+	for t.char != 0 {
+		switch {
+		case depth == 0 && (t.char == '+' || t.char == ',' || t.char == ')'):
+			return
+		case t.char == '(' || t.char == '[' || t.char == '{':
+			depth++
+		case t.char == ')' || t.char == ']' || t.char == '}':
+			depth--
+		case isQuote(t.char):
+			t.skipString(t.char)
+			continue
+		}
+		t.readChar()
+	}
+}
@@ -0,0 +1,115 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+var jsxExtensions = []string{".jsx", ".tsx"}
+
+func isJSXNameChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || strings.ContainsRune("_$.:-", char)
+}
+
+// Skips over a JSX element and its children so that text inside of the element is
+// not mistaken for code. Expressions embedded with `{}` are still tokenized since
+// they can contain dynamic imports. Should be called when the current character is `<`.
+func (t *Tokenizer) skipJSXElement() {
+	t.prevChar, t.prevWord = '<', ""
+	t.readChar()
+	t.skipJSXFiller()
+	// fragments don't have a name or attributes
+	if t.char == '>' {
+		t.readChar()
+		t.skipJSXChildren()
+		return
+	}
+
+	start := t.currentIndex
+	for t.char != 0 && isJSXNameChar(t.char) {
+		t.readChar()
+	}
+	if t.currentIndex == start {
+		// not a JSX tag so just let the tokenizer continue
+		return
+	}
+	t.skipJSXFiller()
+	// In .tsx files generic arrow functions are written as <T,>() => {} or <T extends U>() => {}
+	if t.char == ',' || (t.char == 'e' && t.peekWord() == "extends") {
+		return
+	}
+
+	for t.char != 0 {
+		switch {
+		case t.char == '/' && t.peek() == '>':
+			// self-closing tag
+			t.readChar()
+			t.readChar()
+			t.prevChar = '>'
+			return
+		case t.char == '>':
+			t.readChar()
+			t.skipJSXChildren()
+			return
+		case t.char == '{':
+			t.tokenizeBlock()
+		case t.char == '"' || t.char == '\'':
+			// attribute strings can't contain escapes so there is no need to use skipString
+			t.readStringLiteral()
+		case t.char == '/' && (t.peek() == '/' || t.peek() == '*'):
+			t.skipComment(false)
+		default:
+			t.readChar()
+		}
+	}
+}
+
+// Skips the children and closing tag of a JSX element
+func (t *Tokenizer) skipJSXChildren() {
+	for t.char != 0 {
+		switch t.char {
+		case '<':
+			if t.peekFrom(t.readIndex) == '/' {
+				t.skipJSXClosingTag()
+				return
+			}
+			t.skipJSXElement()
+		case '{':
+			t.tokenizeBlock()
+		default:
+			// This is text so keywords and quotes have no special meaning
+			t.readChar()
+		}
+	}
+}
+
+func (t *Tokenizer) skipJSXClosingTag() {
+	for t.char != 0 && t.char != '>' {
+		t.readChar()
+	}
+	t.prevChar, t.prevWord = '>', ""
+	t.readChar()
+}
+
+// skips whitespace and comments inside of a JSX tag
+func (t *Tokenizer) skipJSXFiller() {
+	for t.char != 0 {
+		switch {
+		case unicode.IsSpace(t.char):
+			t.skipWhitespace()
+		case t.char == '/' && (t.peek() == '/' || t.peek() == '*'):
+			t.skipComment(false)
+		default:
+			return
+		}
+	}
+}
+
+// returns the word starting at the current character without advancing the tokenizer
+func (t *Tokenizer) peekWord() string {
+	end := t.currentIndex
+	for end < t.end() && !isIdentifierEnd(t.fileRunes[end]) && !isQuote(t.fileRunes[end]) {
+		end++
+	}
+	return string(t.fileRunes[t.currentIndex:end])
+}
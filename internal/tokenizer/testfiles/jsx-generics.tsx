@@ -0,0 +1,9 @@
+import { useState } from "react";
+
+export const identity = <T,>(value: T) => value;
+export const constrained = <T extends object>(value: T) => value;
+
+export const Counter = () => {
+  const [count] = useState<number>(0);
+  return <p>{count < 10 ? "import the rest" : "export more"}</p>;
+};
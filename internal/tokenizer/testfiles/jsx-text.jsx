@@ -0,0 +1,29 @@
+import React from "react";
+import { Icon } from "./icon";
+
+// These used to cause panics because the words import and export
+// were tokenized as the start of import or export statements.
+export const Docs = () => (
+  <section className="docs" data-label='import "nothing"'>
+    <h1>How to import and export things</h1>
+    <p>Don't forget: import foo from the store</p>
+    <>
+      export default whatever you want
+      <Icon name="export" />
+    </>
+    <ul>
+      {["a", "b"].map((item) => (
+        <li key={item}>import {item} from require</li>
+      ))}
+    </ul>
+    <button onClick={() => import("./lazy")} icon={<Icon name="import" />}>
+      export
+    </button>
+  </section>
+);
+
+export function isSmaller(a, b) {
+  return a < b;
+}
+
+export const Empty = () => <></>;
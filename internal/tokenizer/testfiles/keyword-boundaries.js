@@ -0,0 +1,15 @@
+import real from "./real";
+
+const myrequire = (x) => x;
+myrequire("./not-required");
+const reimport = "./not-imported";
+obj.import("./not-imported");
+obj?.require("./not-required-either");
+obj.
+  require("./not-required-either");
+const url = new URL("./asset.png", import.meta.url);
+foo.export = { import: "./x", require: "./y", export: "./z" };
+const spread = [...require("./spread")];
+const requirement = require("./required");
+
+export const exported = 5;
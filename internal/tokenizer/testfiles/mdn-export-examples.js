@@ -17,8 +17,7 @@ export const [ name1, name2 ] = array;
 // Export list
 export { name1, /* …, */ nameN };
 export { variable1 as name1, variable2 as name2, /* …, */ nameN };
-// TODO: consider supporting string aliases for exports.
-// export { variable1 as "string name" };
+export { variable1 as "string name" };
 export { name1 as default /*, … */ };
 
 // Default exports
@@ -37,3 +36,5 @@ export { name1, /* …, */ nameN } from "module-name2";
 export { import1 as name1, import2 as name2, /* …, */ nameN } from "module-name3";
 export { default, /* …, */ } from "module-name4";
 export { default as name1 } from "module-name5";
+export { name1 as "string name" } from "module-name6";
+export * as "string namespace" from "module-name7";
@@ -0,0 +1,26 @@
+import a from "./a";
+
+// quotes and comment starts inside of regular expressions
+const quotes = /['"]/g;
+const commentStart = /\/\*/;
+const classSlash = /[/'"]/;
+const split = "it's".split(/'/);
+if (/import "fake"/.test(split)) {
+  const divided = 10 / 2 / 1;
+}
+
+function hasQuote(s) {
+  return /"/.test(s);
+}
+
+// statement conditions can be followed by a regular expression but other parentheses can't
+if (split) /'/.test(split);
+while (false) /"/.exec(split);
+const half = (10 + 2) / 2 / 3;
+
+// dynamic imports inside of template substitutions
+const loaded = `${await import("./in-template")} and ${`nested ${require("./nested")}`}`;
+const notImported = `import b from "./b" isn't real`;
+const object = `${{ key: "value" }.key}`;
+
+import c from "./c";
@@ -0,0 +1,45 @@
+// @ts-nocheck
+export enum Direction {
+  Up,
+  Down,
+}
+export const enum Flags {
+  None = 0,
+  All = 1,
+}
+export declare enum Ambient {
+  A,
+}
+export abstract class Shape<T> {
+  abstract area(): number;
+}
+export declare abstract class DeclaredShape {}
+export declare const declaredConst: number;
+export declare function declaredFunction(): void;
+export declare class DeclaredClass {}
+export namespace Geometry {
+  export const pi = 3.14;
+}
+export namespace Outer.Inner.Deep {
+  export type Depth = number;
+}
+export module LegacyModule {}
+export declare namespace DeclaredNamespace {}
+export async function fetchThings() {}
+export async function* streamThings() {}
+export function *spacedGenerator() {}
+export function * reallySpacedGenerator() {}
+export import Aliased = Geometry.pi;
+export import fs = require("fs");
+declare global {
+  interface Window {
+    dependor: string;
+  }
+}
+export declare global {}
+export as namespace UMDGlobal;
+export = Geometry;
+namespace Internal {
+  export const notExportedFromFile = true;
+}
+export default class DefaultClass {}
@@ -1,9 +1,54 @@
 package tokenizer
 
 type FileToken struct {
-	FilePath    string
-	Imports     map[string][]string
-	ReExports   []string
-	Exports     []string
-	ReExportMap map[string]string
+	FilePath string
+	Imports  map[string][]string
+	// Has an entry for every path in Imports
+	ImportDetails map[string]*ImportDetail
+	ReExports     []string
+	Exports       []string
+	ReExportMap   map[string]string
+	// Files referenced with `/// <reference path="..." />`
+	ReferencePaths []string
+	// Packages referenced with `/// <reference types="..." />`
+	ReferenceTypes []string
+	// Module names declared with `declare module "name" {}`. These can include wildcards.
+	AmbientModules []string
+	// true when the file has a top-level import or export, which makes it a module rather
+	// than a script. `declare module "name"` in a module augments the package instead of
+	// declaring it.
+	IsModule bool
+	// Glob patterns from Vite's `import.meta.glob()`. Negative patterns start with `!`
+	GlobImports []string
+	// Directories imported with webpack's `require.context()`
+	ContextImports []ContextImport
+	// Glob patterns built from import() calls whose specifiers aren't plain strings
+	// e.g. import(`./locales/${lang}.json`) becomes "locales/*.json"
+	DynamicImportPatterns []string
+}
+
+// Information about how a path was imported that isn't needed to build the graph
+type ImportDetail struct {
+	// The path as it was written in the file before relative paths were resolved
+	Specifier string
+	// The 1-based position of the first time the path was imported
+	Line   int
+	Column int
+	// true when the path is only ever imported with import()
+	Dynamic bool
+	// true when the path is only ever imported for types e.g. `import type { Props } from "./button"`.
+	// TypeScript removes these imports when it compiles the file.
+	TypeOnly bool
+	// true when the import was matched from a pattern and may not happen at runtime.
+	// The tokenizer never sets this. It is set by the parser when patterns are expanded.
+	Approximate bool
+}
+
+type ContextImport struct {
+	Directory string
+	// whether files in subdirectories are included
+	Recursive bool
+	// A regular expression files need to match to be included. Like webpack, files
+	// are matched in the form "./path/from/directory.js"
+	Pattern string
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"unicode"
 )
 
@@ -12,15 +13,43 @@ type Tokenizer struct {
 	// points to index of current `char`
 	currentIndex int
 	// points to index of next character to read
-	readIndex   int
-	char        rune
-	fileRunes   []rune
-	imports     map[string][]string
-	reExports   []string
-	reExportMap map[string]string
-	exports     []string
-	callDir     string
-	initPath    string
+	readIndex     int
+	char          rune
+	fileRunes     []rune
+	imports       map[string][]string
+	importDetails map[string]*ImportDetail
+	reExports     []string
+	reExportMap   map[string]string
+	exports       []string
+	callDir       string
+	initPath      string
+	// only .jsx and .tsx files are checked for JSX since `<` can also be
+	// a type assertion in .ts files
+	allowsJSX bool
+	// the last character that was not whitespace or part of a comment and,
+	// if that character ended a word, the word itself. These are used to
+	// guess what a `<` means without parsing expressions.
+	prevChar rune
+	prevWord string
+	// exports inside of namespaces belong to the namespace rather than the file
+	namespaceDepth        int
+	referencePaths        []string
+	referenceTypes        []string
+	ambientModules        []string
+	isModule              bool
+	globImports           []string
+	contextImports        []ContextImport
+	dynamicImportPatterns []string
+	// functions like jest.mock() whose first argument is a module path
+	moduleReferenceCalls []string
+	// maps import paths to the specifier they were first written as
+	specifiers map[string]specifierSource
+}
+
+type specifierSource struct {
+	specifier string
+	// index of the rune the specifier starts at
+	index int
 }
 
 func NewTokenizerFromFile(initPath string) (*Tokenizer, error) {
@@ -34,15 +63,18 @@ func NewTokenizerFromFile(initPath string) (*Tokenizer, error) {
 
 func New(fileString, initPath string) *Tokenizer {
 	t := Tokenizer{
-		currentIndex: -1,
-		readIndex:    0,
-		fileRunes:    []rune(fileString),
-		imports:      make(map[string][]string, 0),
-		reExports:    []string{},
-		reExportMap:  nil,
-		exports:      []string{},
-		callDir:      filepath.Dir(initPath),
-		initPath:     initPath,
+		currentIndex:  -1,
+		readIndex:     0,
+		fileRunes:     []rune(fileString),
+		imports:       make(map[string][]string, 0),
+		importDetails: make(map[string]*ImportDetail, 0),
+		specifiers:    make(map[string]specifierSource, 0),
+		reExports:     []string{},
+		reExportMap:   nil,
+		exports:       []string{},
+		callDir:       filepath.Dir(initPath),
+		initPath:      initPath,
+		allowsJSX:     slices.Contains(jsxExtensions, filepath.Ext(initPath)),
 	}
 	t.readChar()
 	return &t
@@ -54,44 +86,258 @@ func (t *Tokenizer) Tokenize() FileToken {
 	}
 
 	for t.char != 0 {
-		if t.char == 'i' || t.char == 'r' || t.char == 'e' {
-			switch token := t.readIdentifier(); token {
-			case "import":
-				t.readImport()
-			case "require":
-				t.readRequire()
-			case "export":
-				t.readExport()
+		t.step()
+	}
+
+	return FileToken{
+		FilePath:              t.initPath,
+		Imports:               t.imports,
+		ImportDetails:         t.importDetails,
+		ReExports:             t.reExports,
+		Exports:               t.exports,
+		ReExportMap:           t.reExportMap,
+		ReferencePaths:        t.referencePaths,
+		ReferenceTypes:        t.referenceTypes,
+		AmbientModules:        t.ambientModules,
+		IsModule:              t.isModule,
+		GlobImports:           t.globImports,
+		ContextImports:        t.contextImports,
+		DynamicImportPatterns: t.dynamicImportPatterns,
+	}
+}
+
+// Tokenizes the next piece of top level code
+func (t *Tokenizer) step() {
+	switch {
+	case isWordChar(t.char):
+		t.readKeyword()
+	case t.char == '/' && (t.peek() == '/' || t.peek() == '*'):
+		// comments could contain keywords in them but should not be parsed as imports / exports
+		t.skipComment(true)
+	case t.char == '/' && t.atExpressionStart():
+		// a slash that can't be division starts a regular expression
+		t.readRegex()
+	case isQuote(t.char):
+		t.prevChar, t.prevWord = t.char, ""
+		t.skipString(t.char)
+	case t.char == '<' && t.allowsJSX && t.atExpressionStart():
+		t.skipJSXElement()
+	default:
+		if !unicode.IsSpace(t.char) {
+			t.prevChar, t.prevWord = t.char, ""
+		}
+		// avoids reading char if a previous read got us to EOF
+		t.readChar()
+	}
+}
+
+// Reads a whole word and, if the word is an import, require or export keyword at a
+// real token boundary, reads the statement it starts. Keywords used as property
+// names (e.g. obj.import() or { export: x }) are ignored.
+func (t *Tokenizer) readKeyword() {
+	isMemberAccess := t.afterMemberAccess()
+	afterDeclare := t.prevWord == "declare"
+	word := t.readWord()
+	next := t.peekFrom(t.currentIndex)
+	switch {
+	case isMemberAccess || next == ':':
+	case word == "import" && next == '.':
+		t.readImportMeta()
+	case word == "import":
+		t.readImport()
+	case word == "require" && next == '(':
+		t.readRequire()
+	case word == "require" && next == '.':
+		t.readRequireMember()
+	case next == '(' && slices.Contains(t.moduleReferenceCalls, word):
+		t.readRequire()
+	case next == '.' && t.startsModuleReferenceCall(word):
+		t.readModuleReferenceCall(word)
+	case word == "export":
+		t.readExport()
+	case (word == "namespace" || word == "module") && (isWordChar(next) || isQuote(next)):
+		t.readNamespace(afterDeclare)
+	}
+	t.recordPrevToken()
+}
+
+// Sets the functions that should be treated as imports of their first argument
+// e.g. "jest.mock" for jest.mock("./foo")
+func (t *Tokenizer) SetModuleReferenceCalls(calls []string) {
+	t.moduleReferenceCalls = calls
+}
+
+func (t *Tokenizer) startsModuleReferenceCall(word string) bool {
+	for _, call := range t.moduleReferenceCalls {
+		if strings.HasPrefix(call, word+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Reads the rest of a call like jest.mock("./foo") once the first word has been read
+func (t *Tokenizer) readModuleReferenceCall(word string) {
+	call := strings.Join(append([]string{word}, t.readMemberNames()...), ".")
+	if slices.Contains(t.moduleReferenceCalls, call) && t.peekFrom(t.currentIndex) == '(' {
+		t.readRequire()
+	}
+}
+
+// Namespaces (and TypeScript's older `module` syntax) can contain exports of their
+// own that are not exported from the file. Reads a namespace's name and then its body.
+func (t *Tokenizer) readNamespace(isDeclared bool) {
+	t.skipAllFiller()
+	if isQuote(t.char) {
+		// `declare module "foo" {}` declares a module that can be imported elsewhere.
+		// Relative names augment existing modules rather than declaring new ones. Whether
+		// the declaration is ambient or augments a package depends on the file, which the
+		// parser checks.
+		if name := t.readStringLiteral(); isDeclared && !isRelativePath(name) {
+			t.ambientModules = append(t.ambientModules, name)
+		}
+	} else {
+		t.readIdentifier()
+	}
+	t.readNamespaceBody()
+}
+
+// Tokenizes a namespace body without recording any of the exports inside of it
+func (t *Tokenizer) readNamespaceBody() {
+	t.skipAllFiller()
+	if t.char != '{' {
+		return
+	}
+	t.namespaceDepth++
+	t.tokenizeBlock()
+	t.namespaceDepth--
+}
+
+// Checks whether the word starting at the current character is a property being accessed
+// with `.` or `?.`. Spread syntax is not a property access e.g. [...require("./foo")]
+func (t *Tokenizer) afterMemberAccess() bool {
+	if t.prevChar != '.' {
+		return false
+	}
+	i := t.currentIndex - 1
+	for i >= 0 && unicode.IsSpace(t.fileRunes[i]) {
+		i--
+	}
+	return i < 1 || t.fileRunes[i] != '.' || t.fileRunes[i-1] != '.'
+}
+
+// Tokenizes code until reaching the brace that closes the current block.
+// Should be called when the current character is the opening brace.
+func (t *Tokenizer) tokenizeBlock() {
+	t.prevChar, t.prevWord = '{', ""
+	t.readChar()
+	depth := 0
+	for t.char != 0 {
+		switch t.char {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				t.prevChar, t.prevWord = '}', ""
+				t.readChar()
+				return
 			}
-		} else if t.char == '/' {
-			// comments could contain keywords in them but should not be parsed as imports / exports
-			t.skipComment(true)
-		} else if isQuote(t.char) {
-			t.skipString(t.char)
-		} else {
-			// avoids reading char if a previous read got us to EOF
-			t.readChar()
+			depth--
 		}
+		t.step()
 	}
+}
 
-	return FileToken{
-		FilePath:    t.initPath,
-		Imports:     t.imports,
-		ReExports:   t.reExports,
-		Exports:     t.exports,
-		ReExportMap: t.reExportMap,
+// Guesses whether the tokenizer is at a position where an expression can begin.
+// This is what separates regular expressions from division and JSX tags from
+// less than operators and generics.
+func (t *Tokenizer) atExpressionStart() bool {
+	if t.prevWord != "" {
+		return slices.Contains(expressionKeywords, t.prevWord)
+	}
+	if t.prevChar == ')' {
+		return t.closesStatementCondition()
+	}
+	return t.prevChar == 0 || strings.ContainsRune(expressionStartChars, t.prevChar)
+}
+
+// A `)` usually ends an operand e.g. (a + b) / 2, but the condition of an if, while, for
+// or with statement is followed by a statement that can start with an expression e.g.
+// if (x) /'/.test(y). Looks backwards for the matching `(` and the word before it.
+func (t *Tokenizer) closesStatementCondition() bool {
+	i := t.currentIndex - 1
+	for i >= 0 && unicode.IsSpace(t.fileRunes[i]) {
+		i--
+	}
+	if i < 0 || t.fileRunes[i] != ')' {
+		return false
+	}
+	depth := 0
+	for ; i >= 0; i-- {
+		if t.fileRunes[i] == ')' {
+			depth++
+		} else if t.fileRunes[i] == '(' {
+			depth--
+			if depth == 0 {
+				break
+			}
+		}
+	}
+	i--
+	for i >= 0 && unicode.IsSpace(t.fileRunes[i]) {
+		i--
+	}
+	end := i + 1
+	for i >= 0 && isWordChar(t.fileRunes[i]) {
+		i--
+	}
+	return slices.Contains(statementConditionKeywords, string(t.fileRunes[i+1:end]))
+}
+
+// Looks backwards from the current character to find the previous token. This is
+// needed after reading statements that can stop anywhere inside of a declaration.
+func (t *Tokenizer) recordPrevToken() {
+	i := t.currentIndex - 1
+	if t.char == 0 {
+		i = t.end() - 1
+	}
+	for i >= 0 && unicode.IsSpace(t.fileRunes[i]) {
+		i--
+	}
+	if i < 0 {
+		t.prevChar, t.prevWord = 0, ""
+		return
+	}
+	t.prevChar, t.prevWord = t.fileRunes[i], ""
+	end := i + 1
+	for i >= 0 && isWordChar(t.fileRunes[i]) {
+		i--
+	}
+	if end-1 > i {
+		t.prevWord = string(t.fileRunes[i+1 : end])
 	}
 }
 
 // Export cases: https://developer.mozilla.org/en-US/docs/web/javascript/reference/statements/export
 func (t *Tokenizer) readExport() {
+	if t.namespaceDepth == 0 {
+		t.isModule = true
+	}
 	var identifiers []string
 	isReExport := false
 	haveSeenLeftBrace := false
-	// classes and interfaces should always export the next identifier
+	// classes, interfaces, enums and namespaces should always export the next identifier
 	// we could also look for end chars ('{' and 'extends' in this case)
 	// but this seems like a better way to avoid potential edge cases
-	haveSeenInterfaceOrClass := false
+	exportNextIdentifier := false
+	// used to skip the `*` in `function *generator()`
+	haveSeenFunction := false
+	// used to recognize `declare global { ... }` which doesn't export anything
+	haveSeenDeclare := false
+	// TypeScript's `export import fs = require("fs")` imports like `import fs = require("fs")`
+	haveSeenImport := false
+	// namespace bodies need to be read so that their exports can be ignored
+	isNamespace := false
 	// '}' is also sort of an an endChar. e.g. export { foo, bar } w/o semi-colon
 	// but it's ambiguous because for re-exports we'd need to continue. I'm not sure
 	// there's any way to handle this case besides looking ahead afterwards
@@ -105,6 +351,10 @@ Loop:
 		switch {
 		// this needs to come before isIdentifierEnd check because some of these chars are shared
 		case slices.Contains(endChars, t.char):
+			// TypeScript's `export = foo` is imported as a default import
+			if t.char == '=' && len(identifiers) == 0 && !haveSeenLeftBrace {
+				identifiers = append(identifiers, "default")
+			}
 			break Loop
 		case t.char == '{':
 			haveSeenLeftBrace = true
@@ -125,7 +375,18 @@ Loop:
 		case isIdentifierEnd(t.char):
 			t.readChar()
 		case isQuote(t.char):
-			panic(fmt.Sprintf("Encountered a quote in an export statement that was not preceded by the `from` keyword in %q. This may be a syntax error or it could be a quoted export alias. Dependor does not currently support quoted import aliases.", t.initPath))
+			// ES2022 allows arbitrary strings as export names e.g. export { foo as "some name" }
+			// but they can only show up inside of braces or as a namespace alias
+			if !haveSeenLeftBrace && !overwriteLastIdentifier {
+				panic(fmt.Sprintf("Encountered a quote in an export statement that was not preceded by the `from` keyword in %q. This is likely a syntax error.", t.initPath))
+			}
+			name := t.readStringLiteral()
+			if overwriteLastIdentifier {
+				identifiers[len(identifiers)-1] = name
+				overwriteLastIdentifier = false
+				continue
+			}
+			identifiers = append(identifiers, name)
 		default:
 			ident := t.readIdentifier()
 			// I don't think this case can happen, but if it does, this will avoid an infinite loop
@@ -134,20 +395,49 @@ Loop:
 			}
 			switch ident {
 			case "as":
+				if len(identifiers) == 0 && !haveSeenLeftBrace {
+					// `export as namespace Foo` declares a UMD global rather than an export
+					t.skipAllFiller()
+					t.readIdentifier()
+					t.skipAllFiller()
+					t.readIdentifier()
+					return
+				}
 				overwriteLastIdentifier = true
 			case "from":
 				isReExport = true
 				break Loop
-			case "interface", "class":
-				haveSeenInterfaceOrClass = true
-			case "const", "let", "var", "function", "function*", "type":
+			case "namespace", "module":
+				isNamespace = true
+				exportNextIdentifier = true
+			case "interface", "class", "enum":
+				exportNextIdentifier = true
+			case "function", "function*":
+				haveSeenFunction = true
+			case "declare":
+				haveSeenDeclare = true
+			// `import` shows up in TypeScript's `export import A = B.C`
+			case "import":
+				haveSeenImport = true
+			case "const", "let", "var", "type", "abstract", "async":
 				continue
 			default:
 				if ident == "default" && !haveSeenLeftBrace {
 					identifiers = append(identifiers, ident)
 					break Loop
 				}
-				if haveSeenInterfaceOrClass {
+				if ident == "global" && haveSeenDeclare && len(identifiers) == 0 {
+					break Loop
+				}
+				if haveSeenFunction {
+					if ident == "*" {
+						continue
+					}
+					ident = strings.TrimPrefix(ident, "*")
+				}
+				if exportNextIdentifier {
+					// namespaces can be declared with dotted names e.g. namespace A.B.C {}
+					ident, _, _ = strings.Cut(ident, ".")
 					identifiers = append(identifiers, ident)
 					break Loop
 				}
@@ -172,7 +462,15 @@ Loop:
 	}
 
 	if !isReExport {
-		t.exports = append(t.exports, identifiers...)
+		if t.namespaceDepth == 0 {
+			t.exports = append(t.exports, identifiers...)
+		}
+		if isNamespace {
+			t.readNamespaceBody()
+		}
+		if haveSeenImport && t.char == '=' {
+			t.readImportEquals()
+		}
 		return
 	}
 
@@ -209,10 +507,24 @@ Loop:
 // is pretty complex because the spec allows many unicode characters including emojis. Instead
 // we just assume any non-whitespace character not in identifier_ends is a valid identifier char
 func (t *Tokenizer) readImport() {
+	if t.peekFrom(t.currentIndex) == '(' {
+		t.readDynamicImport()
+		return
+	}
+	if t.namespaceDepth == 0 {
+		t.isModule = true
+	}
 	var identifiers []string
 	skipNextIdentifier := false
 	// used to determine if import is a default import
 	haveSeenLeftBrace := false
+	// quotes inside of braces are string import names e.g. import { "some name" as foo }
+	insideBraces := false
+	// `import type { A } from` only imports types and so does `import { type A } from`
+	// when every name is marked as a type
+	isTypeImport := false
+	nextIsType := false
+	typeIdentifiers := 0
 	for t.char != 0 {
 		switch {
 		case t.char == '/':
@@ -220,14 +532,27 @@ func (t *Tokenizer) readImport() {
 		case isIdentifierEnd(t.char):
 			if t.char == '{' {
 				haveSeenLeftBrace = true
+				insideBraces = true
+			}
+			if t.char == '}' {
+				insideBraces = false
 			}
 			t.readChar()
 		case t.char == ')':
 			// import() can import using a variable rather than a string I think
 			return
+		case isQuote(t.char) && insideBraces:
+			identifiers = append(identifiers, t.readStringLiteral())
+		case t.char == '=':
+			t.readImportEquals()
+			return
 		case isQuote(t.char):
 			importPath := t.readPathString()
-			t.imports[importPath] = append(t.imports[importPath], identifiers...)
+			if isTypeImport || (haveSeenLeftBrace && len(identifiers) > 0 && typeIdentifiers == len(identifiers)) {
+				t.addTypeImport(importPath, identifiers...)
+				return
+			}
+			t.addImport(importPath, identifiers...)
 			return
 		default:
 			ident := t.readIdentifier()
@@ -239,8 +564,15 @@ func (t *Tokenizer) readImport() {
 			switch ident {
 			case "as":
 				skipNextIdentifier = true
+			case "from":
+				continue
 			// Some typescript setups annotate imports of types as `import type { ... } ...`
-			case "from", "type":
+			case "type":
+				if insideBraces {
+					nextIsType = true
+				} else if len(identifiers) == 0 && !haveSeenLeftBrace {
+					isTypeImport = true
+				}
 				continue
 			default:
 				if skipNextIdentifier {
@@ -251,6 +583,10 @@ func (t *Tokenizer) readImport() {
 				if !haveSeenLeftBrace && ident != "*" {
 					ident = "default"
 				}
+				if nextIsType {
+					typeIdentifiers++
+					nextIsType = false
+				}
 				identifiers = append(identifiers, ident)
 			}
 		}
@@ -258,12 +594,84 @@ func (t *Tokenizer) readImport() {
 	panic(fmt.Sprintf("Encountered non-terminating import statement in %q. This is likely a syntax error.", t.initPath))
 }
 
+// TypeScript's `import fs = require("fs")` imports the whole module like a namespace import.
+// `import A = B.C` aliases a namespace and doesn't import anything. Should be called when
+// the current character is `=`.
+func (t *Tokenizer) readImportEquals() {
+	t.readChar()
+	t.skipAllFiller()
+	if t.readIdentifier() != "require" {
+		return
+	}
+	t.skipAllFiller()
+	if t.char != '(' {
+		return
+	}
+	t.readChar()
+	t.skipAllFiller()
+	if !isQuote(t.char) {
+		return
+	}
+	t.addImport(t.readPathString(), "*")
+}
+
+// Records an import path along with any identifiers imported from it. Paths can be
+// imported more than once so identifiers are added to any that were already found.
+func (t *Tokenizer) addImport(importPath string, identifiers ...string) {
+	if _, ok := t.imports[importPath]; !ok {
+		t.imports[importPath] = []string{}
+	}
+	t.imports[importPath] = append(t.imports[importPath], identifiers...)
+	// a static import means the path is always loaded even if it is also imported dynamically
+	detail := t.importDetail(importPath)
+	detail.Dynamic = false
+	detail.TypeOnly = false
+}
+
+// Records an import that only imports types. Paths are only considered type-only
+// if every import of them is.
+func (t *Tokenizer) addTypeImport(importPath string, identifiers ...string) {
+	_, alreadyImported := t.imports[importPath]
+	t.imports[importPath] = append(t.imports[importPath], identifiers...)
+	if !alreadyImported {
+		t.importDetail(importPath).TypeOnly = true
+	}
+}
+
+// Records a path imported with import(). Paths are only considered dynamic if they
+// are never imported statically.
+func (t *Tokenizer) addDynamicImport(importPath string) {
+	_, alreadyImported := t.imports[importPath]
+	detail := t.importDetail(importPath)
+	if !alreadyImported || detail.TypeOnly {
+		if !alreadyImported {
+			t.imports[importPath] = []string{}
+		}
+		detail.Dynamic = true
+		detail.TypeOnly = false
+	}
+}
+
+func (t *Tokenizer) importDetail(importPath string) *ImportDetail {
+	detail, ok := t.importDetails[importPath]
+	if !ok {
+		source, ok := t.specifiers[importPath]
+		if !ok {
+			source = specifierSource{specifier: importPath, index: t.currentIndex}
+		}
+		line, column := t.lineAndColumn(source.index)
+		detail = &ImportDetail{Specifier: source.specifier, Line: line, Column: column}
+		t.importDetails[importPath] = detail
+	}
+	return detail
+}
+
 // skips to first non-whitespace non-comment character
 func (t *Tokenizer) skipAllFiller() {
 Loop:
 	for t.char != 0 {
 		switch {
-		case t.char == '/':
+		case t.char == '/' && (t.peek() == '/' || t.peek() == '*'):
 			t.skipComment(false)
 		case unicode.IsSpace(t.char):
 			t.skipWhitespace()
@@ -281,8 +689,7 @@ func (t *Tokenizer) readRequire() {
 		case t.char == '/':
 			t.skipComment(false)
 		case isQuote(t.char):
-			requirePath := t.readPathString()
-			t.imports[requirePath] = []string{}
+			t.addImport(t.readPathString())
 			return
 		default:
 			t.readChar()
@@ -318,14 +725,46 @@ func (t *Tokenizer) skipComment(standaloneSlashIsSafe bool) {
 
 // skips to first character that is not part of a single line comment
 func (t *Tokenizer) skipSingleLineComment() {
+	// the first slash has already been read
+	start := t.currentIndex - 1
 	for t.char != 0 && t.char != '\n' && t.char != '\r' {
 		t.readChar()
 	}
+	end := t.currentIndex
+	if t.char == 0 {
+		end = t.end()
+	}
+	if comment := string(t.fileRunes[start:end]); strings.HasPrefix(comment, "///") {
+		t.readTripleSlashDirective(comment, start)
+	}
 	if t.char != 0 {
 		t.readChar()
 	}
 }
 
+// TypeScript's `/// <reference path="..." />` and `/// <reference types="..." />`
+// directives are dependencies even though they are written as comments. They only
+// matter when type checking so they are type-only imports.
+// Reference paths are always relative to the file they are written in.
+// `start` is the index the comment starts at.
+func (t *Tokenizer) readTripleSlashDirective(comment string, start int) {
+	match := referenceDirectivePattern.FindStringSubmatch(comment)
+	if match == nil {
+		return
+	}
+	switch kind, reference := match[1], match[2]; kind {
+	case "path":
+		referencePath := filepath.Join(t.callDir, reference)
+		t.rememberSpecifier(referencePath, reference, start)
+		t.referencePaths = append(t.referencePaths, referencePath)
+		t.addTypeImport(referencePath)
+	case "types":
+		t.rememberSpecifier(reference, reference, start)
+		t.referenceTypes = append(t.referenceTypes, reference)
+		t.addTypeImport(reference)
+	}
+}
+
 // Skips to first character that is not part of a multilinie comment
 func (t *Tokenizer) skipMultiLineComment() {
 	t.readChar()
@@ -339,6 +778,22 @@ func (t *Tokenizer) skipMultiLineComment() {
 	t.readChar()
 }
 
+// reads a run of word characters. Unlike readIdentifier this stops at any
+// character that can't be part of a JavaScript identifier.
+func (t *Tokenizer) readWord() string {
+	if t.char == 0 {
+		return ""
+	}
+	start := t.currentIndex
+	for isWordChar(t.char) {
+		t.readChar()
+	}
+	if t.char == 0 {
+		return string(t.fileRunes[start:])
+	}
+	return string(t.fileRunes[start:t.currentIndex])
+}
+
 func (t *Tokenizer) readIdentifier() string {
 	start := t.currentIndex
 	for t.char != 0 && !isIdentifierEnd(t.char) && !isQuote(t.char) {
@@ -348,53 +803,138 @@ func (t *Tokenizer) readIdentifier() string {
 }
 
 func (t *Tokenizer) readPathString() string {
+	start := t.currentIndex
+	return t.pathFromSpecifier(t.readStringLiteral(), start)
+}
+
+// Converts relative specifiers to paths from the project root. The original specifier
+// and where it starts are remembered so they can be reported in the path's ImportDetail.
+func (t *Tokenizer) pathFromSpecifier(specifier string, start int) string {
+	importPath := specifier
+	if isRelativePath(specifier) {
+		importPath = filepath.Join(t.callDir, specifier)
+	}
+	t.rememberSpecifier(importPath, specifier, start)
+	return importPath
+}
+
+func (t *Tokenizer) rememberSpecifier(importPath, specifier string, start int) {
+	if _, ok := t.specifiers[importPath]; !ok {
+		t.specifiers[importPath] = specifierSource{specifier: specifier, index: start}
+	}
+}
+
+// Converts a rune index into a 1-based line and column
+func (t *Tokenizer) lineAndColumn(index int) (int, int) {
+	line, column := 1, 1
+	for i := 0; i < index && i < len(t.fileRunes); i++ {
+		if t.fileRunes[i] == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return line, column
+}
+
+// Reads the contents of a string literal without any path handling.
+// Should be called when the current character is the opening quote.
+func (t *Tokenizer) readStringLiteral() string {
+	quote := t.char
 	// should be starting on a quote so we need to advance to first nonquote
 	t.readChar()
 	start := t.currentIndex
-	for t.currentIndex < t.end() {
-		if isQuote(t.char) {
-			break
-		}
+	for t.char != 0 && t.char != quote {
 		t.readChar()
 	}
 
-	if t.currentIndex == t.end() {
+	if t.char == 0 {
 		// Panic here since syntax errors like this could silently cause the
 		// resultant import graph to be incorrect.
 		panic(fmt.Sprintf("Error: tokenizer came across a non-terminating string in %q. This is likely a syntax error.\n", t.initPath))
 	}
 
-	pathString := string(t.fileRunes[start:t.currentIndex])
-	if isRelativePath(pathString) {
-		pathString = filepath.Join(t.callDir, pathString)
-	}
+	literal := string(t.fileRunes[start:t.currentIndex])
 	t.readChar()
-	return pathString
+	return literal
 }
 
 // need to skip strings to avoid treating code inside of strings as real code.
-// This needs to be a recursive process because a string can be nested inside
-// of a string. We also need to handle escaped strings
+// Quotes of a different kind than the starting quote have no special meaning
+// inside of a string, but we do need to handle escaped quotes.
 func (t *Tokenizer) skipString(startChar rune) {
+	if startChar == '`' {
+		t.skipTemplate()
+		return
+	}
+	t.readChar()
+	for t.char != 0 {
+		switch t.char {
+		case '\\':
+			t.readChar()
+		case startChar:
+			t.readChar()
+			return
+		}
+		t.readChar()
+	}
+	panic(fmt.Sprintf("Error: tokenizer came accross a non-terminating string in %q. This is likely a syntax error.\n", t.initPath))
+}
+
+// Template literals can have code inside of `${}` substitutions, which can in turn
+// contain other template literals or dynamic imports, so substitutions are tokenized
+// like any other block of code.
+func (t *Tokenizer) skipTemplate() {
 	t.readChar()
-	isEscaped := false
-	for t.currentIndex < t.end() {
-		if !isEscaped && t.char == '\\' {
-			isEscaped = true
+	for t.char != 0 {
+		switch {
+		case t.char == '\\':
+			t.readChar()
+		case t.char == '`':
+			t.readChar()
+			return
+		case t.char == '$' && t.peek() == '{':
 			t.readChar()
+			t.tokenizeBlock()
 			continue
 		}
-		if !isEscaped && isQuote(t.char) {
-			if t.char == startChar {
-				t.readChar()
-				return
+		t.readChar()
+	}
+	panic(fmt.Sprintf("Error: tokenizer came accross a non-terminating template literal in %q. This is likely a syntax error.\n", t.initPath))
+}
+
+// Reads a regular expression literal and returns it in a form Go's regexp package can
+// compile. Quotes have no special meaning inside of a regular expression and slashes
+// inside of a character class don't end the expression e.g. /[/'"]/
+func (t *Tokenizer) readRegex() string {
+	t.readChar()
+	start := t.currentIndex
+	inCharacterClass := false
+	for t.char != 0 && t.char != '\n' {
+		switch {
+		case t.char == '\\':
+			t.readChar()
+		case t.char == '[':
+			inCharacterClass = true
+		case t.char == ']':
+			inCharacterClass = false
+		case t.char == '/' && !inCharacterClass:
+			pattern := string(t.fileRunes[start:t.currentIndex])
+			t.readChar()
+			flags := t.readWord()
+			// a regex is an operand and '/' is not treated as an expression start
+			t.prevChar, t.prevWord = '/', ""
+			for _, flag := range "ims" {
+				if strings.ContainsRune(flags, flag) {
+					pattern = "(?" + string(flag) + ")" + pattern
+				}
 			}
-			t.skipString(t.char)
+			return pattern
 		}
-		isEscaped = false
 		t.readChar()
 	}
-	panic(fmt.Sprintf("Error: tokenizer came accross a non-terminating string in %q. This is likely a syntax error.\n", t.initPath))
+	panic(fmt.Sprintf("Error: tokenizer came across a non-terminating regular expression in %q. This is likely a syntax error.\n", t.initPath))
 }
 
 func (t *Tokenizer) readChar() {
@@ -407,6 +947,33 @@ func (t *Tokenizer) readChar() {
 	t.currentIndex++
 }
 
+// returns the first character at or after index i that is not whitespace or part
+// of a comment without advancing the tokenizer
+func (t *Tokenizer) peekFrom(i int) rune {
+	if t.char == 0 {
+		return 0
+	}
+	for i < t.end() {
+		switch {
+		case unicode.IsSpace(t.fileRunes[i]):
+			i++
+		case t.fileRunes[i] == '/' && i+1 < t.end() && t.fileRunes[i+1] == '/':
+			for i < t.end() && t.fileRunes[i] != '\n' {
+				i++
+			}
+		case t.fileRunes[i] == '/' && i+1 < t.end() && t.fileRunes[i+1] == '*':
+			i += 2
+			for i+1 < t.end() && !(t.fileRunes[i] == '*' && t.fileRunes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		default:
+			return t.fileRunes[i]
+		}
+	}
+	return 0
+}
+
 func (t *Tokenizer) end() int {
 	return len(t.fileRunes)
 }
@@ -271,16 +271,17 @@ func TestReExports(t *testing.T) {
 
 func TestMdnImports(t *testing.T) {
 	expected := map[string][]string{
-		"module-name0": {"default"},
-		"module-name1": {"*"},
-		"module-name2": {"export1"},
-		"module-name3": {"export1"},
-		"module-name4": {"default"},
-		"module-name5": {"export1", "export2"},
-		"module-name6": {"export1", "export2"},
-		"module-name7": {"default", "export1"},
-		"module-name8": {"default", "*"},
-		"module-name9": {},
+		"module-name0":  {"default"},
+		"module-name1":  {"*"},
+		"module-name2":  {"export1"},
+		"module-name3":  {"export1"},
+		"module-name4":  {"default"},
+		"module-name5":  {"export1", "export2"},
+		"module-name6":  {"export1", "export2"},
+		"module-name7":  {"default", "export1"},
+		"module-name8":  {"default", "*"},
+		"module-name9":  {},
+		"module-name10": {"string name"},
 	}
 
 	tokenizer, err := NewTokenizerFromFile("./testfiles/mdn-import-examples.js")
@@ -307,6 +308,7 @@ func TestMdnExports(t *testing.T) {
 		"name1",
 		"name2",
 		"nameN",
+		"string name",
 		"default",
 		"default",
 		"default",
@@ -319,6 +321,28 @@ func TestMdnExports(t *testing.T) {
 	testFileExports(t, "./testfiles/mdn-export-examples.js", expectedExports)
 }
 
+func TestQuotedReExports(t *testing.T) {
+	tokenizer := New(`export { foo as "some name", "other name" } from "./foo";
+export { "a b" as "c d" } from "./bar";
+export * as "namespace name" from "./baz";`, ".")
+	tokenizedFile := tokenizer.Tokenize()
+	expectedReExportMap := map[string]string{
+		"some name":      "foo",
+		"other name":     "foo",
+		"c d":            "bar",
+		"namespace name": "baz",
+	}
+	if len(tokenizedFile.ReExportMap) != len(expectedReExportMap) {
+		t.Log(tokenizedFile.ReExportMap)
+		t.Fatalf("Expected %d entries in the re-export map but received %d", len(expectedReExportMap), len(tokenizedFile.ReExportMap))
+	}
+	for k, v := range expectedReExportMap {
+		if tokenizedFile.ReExportMap[k] != v {
+			t.Errorf("Expected key %q to map to %q but received %q", k, v, tokenizedFile.ReExportMap[k])
+		}
+	}
+}
+
 func TestInterfacesAndClasses(t *testing.T) {
 	expectedExports := []string{
 		"Extender",
@@ -341,6 +365,32 @@ func TestTypes(t *testing.T) {
 	testFileExports(t, "./testfiles/types.tsx", expectedExports)
 }
 
+func TestTypeScriptExportDeclarations(t *testing.T) {
+	expectedExports := []string{
+		"Direction",
+		"Flags",
+		"Ambient",
+		"Shape",
+		"DeclaredShape",
+		"declaredConst",
+		"declaredFunction",
+		"DeclaredClass",
+		"Geometry",
+		"Outer",
+		"LegacyModule",
+		"DeclaredNamespace",
+		"fetchThings",
+		"streamThings",
+		"spacedGenerator",
+		"reallySpacedGenerator",
+		"Aliased",
+		"fs",
+		"default",
+		"default",
+	}
+	testFileExports(t, "./testfiles/ts-export-declarations.ts", expectedExports)
+}
+
 func TestEdgeCases(t *testing.T) {
 	expectedExports := []string{
 		"imports",
@@ -349,6 +399,230 @@ func TestEdgeCases(t *testing.T) {
 	testFileExports(t, "./testfiles/edge-cases.tsx", expectedExports)
 }
 
+func TestJSXText(t *testing.T) {
+	tokenizer, err := NewTokenizerFromFile("./testfiles/jsx-text.jsx")
+	if err != nil {
+		t.Fatalf("Expected successful file read. Got error: %s", err)
+	}
+	tokenizedFile := tokenizer.Tokenize()
+	expectedImports := map[string][]string{
+		"react":          {"default"},
+		"testfiles/icon": {"Icon"},
+		"testfiles/lazy": {},
+	}
+	testEdgeList(t, tokenizedFile.Imports, expectedImports)
+	testArray(t, tokenizedFile.Exports, []string{"Docs", "isSmaller", "Empty"})
+}
+
+func TestJSXGenerics(t *testing.T) {
+	tokenizer, err := NewTokenizerFromFile("./testfiles/jsx-generics.tsx")
+	if err != nil {
+		t.Fatalf("Expected successful file read. Got error: %s", err)
+	}
+	tokenizedFile := tokenizer.Tokenize()
+	testEdgeList(t, tokenizedFile.Imports, map[string][]string{"react": {"useState"}})
+	testArray(t, tokenizedFile.Exports, []string{"identity", "constrained", "Counter"})
+}
+
+func TestRegexAndTemplateLiterals(t *testing.T) {
+	tokenizer, err := NewTokenizerFromFile("./testfiles/regex-and-templates.js")
+	if err != nil {
+		t.Fatalf("Expected successful file read. Got error: %s", err)
+	}
+	tokenizedFile := tokenizer.Tokenize()
+	expectedImports := map[string][]string{
+		"testfiles/a":           {"default"},
+		"testfiles/in-template": {},
+		"testfiles/nested":      {},
+		"testfiles/c":           {"default"},
+	}
+	testEdgeList(t, tokenizedFile.Imports, expectedImports)
+}
+
+func TestKeywordBoundaries(t *testing.T) {
+	tokenizer, err := NewTokenizerFromFile("./testfiles/keyword-boundaries.js")
+	if err != nil {
+		t.Fatalf("Expected successful file read. Got error: %s", err)
+	}
+	tokenizedFile := tokenizer.Tokenize()
+	expectedImports := map[string][]string{
+		"testfiles/real":     {"default"},
+		"testfiles/spread":   {},
+		"testfiles/required": {},
+	}
+	testEdgeList(t, tokenizedFile.Imports, expectedImports)
+	testArray(t, tokenizedFile.Exports, []string{"exported"})
+}
+
+func TestTypeScriptModuleReferences(t *testing.T) {
+	tokenizer := New(`/// <reference path="./globals.d.ts" />
+/// <reference types="node" />
+/// <reference lib="es2017" />
+import fs = require("fs");
+export import path = require("path");
+import Alias = Some.Namespace;
+export import Exported = Some.Namespace;
+declare module "virtual:config" {
+  export const mode: string;
+}
+declare module "*.svg";
+declare module "./augmented" {}
+module "not-declared" {}`, "src/index.d.ts")
+	tokenizedFile := tokenizer.Tokenize()
+	expectedImports := map[string][]string{
+		"src/globals.d.ts": {},
+		"node":             {},
+		"fs":               {"*"},
+		"path":             {"*"},
+	}
+	testEdgeList(t, tokenizedFile.Imports, expectedImports)
+	testArray(t, tokenizedFile.ReferencePaths, []string{"src/globals.d.ts"})
+	testArray(t, tokenizedFile.ReferenceTypes, []string{"node"})
+	testArray(t, tokenizedFile.AmbientModules, []string{"virtual:config", "*.svg"})
+	testArray(t, tokenizedFile.Exports, []string{"path", "Exported"})
+	// import = require is a top-level import
+	if !tokenizedFile.IsModule {
+		t.Error("Expected a file with a top-level import to be a module")
+	}
+
+	script := New(`declare module "virtual:config" {
+  import { Mode } from "./mode";
+  export const mode: Mode;
+}`, "src/virtual.d.ts").Tokenize()
+	if script.IsModule {
+		t.Error("Expected imports and exports inside of a module declaration not to make the file a module")
+	}
+}
+
+func TestBundlerImports(t *testing.T) {
+	tokenizer := New(`const pages = import.meta.glob(["./pages/*.tsx", "!./pages/_*.tsx", "/src/**/*.md"]);
+const eager = import.meta.globEager("./eager/*.js");
+const url = import.meta.url;
+const icons = require.context("./icons", false, /\.SVG$/i);
+const everything = require.context("../everything");
+const worker = require.resolve("./worker");`, "src/routes.js")
+	tokenizedFile := tokenizer.Tokenize()
+	testEdgeList(t, tokenizedFile.Imports, map[string][]string{"src/worker": {}})
+	testArray(t, tokenizedFile.GlobImports, []string{"src/pages/*.tsx", "!src/pages/_*.tsx", "src/**/*.md", "src/eager/*.js"})
+	expectedContexts := []ContextImport{
+		{Directory: "src/icons", Recursive: false, Pattern: `(?i)\.SVG$`},
+		{Directory: "everything", Recursive: true, Pattern: defaultContextPattern},
+	}
+	if len(tokenizedFile.ContextImports) != len(expectedContexts) {
+		t.Fatalf("Expected %d context imports but received %d", len(expectedContexts), len(tokenizedFile.ContextImports))
+	}
+	for i, context := range tokenizedFile.ContextImports {
+		if context != expectedContexts[i] {
+			t.Errorf("Expected context import at index %d to be %+v but received %+v", i, expectedContexts[i], context)
+		}
+	}
+}
+
+func TestDynamicImportPatterns(t *testing.T) {
+	tokenizer := New("const a = import(`./locales/${lang}.json`);\n"+
+		"const b = import('./pages/' + name);\n"+
+		"const c = import(\"./views/\" + folder + \"/\" + `${page}.tsx`);\n"+
+		"const d = import(getPath());\n"+
+		"const e = import(`./plain`);\n"+
+		"const f = import(`~/aliased/${x}`, { with: { type: \"json\" } });\n"+
+		"import \"./static\";\n"+
+		"const g = import(\"./static\");", "src/loader.js")
+	tokenizedFile := tokenizer.Tokenize()
+	testArray(t, tokenizedFile.DynamicImportPatterns, []string{
+		"src/locales/*.json",
+		"src/pages/*",
+		"src/views/*/*.tsx",
+		"~/aliased/*",
+	})
+	testEdgeList(t, tokenizedFile.Imports, map[string][]string{"src/plain": {}, "src/static": {}})
+	if !tokenizedFile.ImportDetails["src/plain"].Dynamic {
+		t.Error("Expected src/plain to be a dynamic import")
+	}
+	if tokenizedFile.ImportDetails["src/static"].Dynamic {
+		t.Error("Expected src/static not to be dynamic since it is also imported statically")
+	}
+}
+
+func TestModuleReferenceCalls(t *testing.T) {
+	tokenizer := New(`jest.mock("./client");
+jest.requireActual("./actual");
+vi.mock("~/store", () => ({}));
+vi.fn("./not-a-module");
+obj.jest.mock("./member");
+mockModule("./plain-function");`, "src/client.test.js")
+	tokenizer.SetModuleReferenceCalls([]string{"jest.mock", "jest.requireActual", "vi.mock", "mockModule"})
+	tokenizedFile := tokenizer.Tokenize()
+	expectedImports := map[string][]string{
+		"src/client":         {},
+		"src/actual":         {},
+		"~/store":            {},
+		"src/plain-function": {},
+	}
+	testEdgeList(t, tokenizedFile.Imports, expectedImports)
+}
+
+func TestImportSpecifiers(t *testing.T) {
+	tokenizedFile := New(`import a from "./a";
+import b from "../lib/b";
+import React from "react";
+const c = await import("./c");
+/// <reference path="globals.d.ts" />
+import again from "./a";`, "src/app/index.ts").Tokenize()
+	expected := map[string]ImportDetail{
+		"src/app/a":            {Specifier: "./a", Line: 1, Column: 15},
+		"src/lib/b":            {Specifier: "../lib/b", Line: 2, Column: 15},
+		"react":                {Specifier: "react", Line: 3, Column: 19},
+		"src/app/c":            {Specifier: "./c", Line: 4, Column: 24, Dynamic: true},
+		"src/app/globals.d.ts": {Specifier: "globals.d.ts", Line: 5, Column: 1, TypeOnly: true},
+	}
+	for importPath, expectedDetail := range expected {
+		detail, ok := tokenizedFile.ImportDetails[importPath]
+		if !ok {
+			t.Errorf("Expected import details for %q", importPath)
+			continue
+		}
+		if *detail != expectedDetail {
+			t.Errorf("Expected %q to have details %+v. Got %+v", importPath, expectedDetail, *detail)
+		}
+	}
+}
+
+func TestTypeOnlyImports(t *testing.T) {
+	tokenizedFile := New(`import type { Props } from "./props";
+import { type Theme, type Color } from "./theme";
+import { type Size, scale } from "./size";
+import type Config from "./config";
+import type { State } from "./store";
+import { useStore } from "./store";
+import type { Lazy } from "./lazy";
+const lazy = await import("./lazy");`, "src/button.ts").Tokenize()
+	expected := map[string]bool{
+		"src/props":  true,
+		"src/theme":  true,
+		"src/size":   false,
+		"src/config": true,
+		// value imports of a path make it a runtime dependency
+		"src/store": false,
+		"src/lazy":  false,
+	}
+	for importPath, typeOnly := range expected {
+		detail, ok := tokenizedFile.ImportDetails[importPath]
+		if !ok {
+			t.Errorf("Expected import details for %q", importPath)
+			continue
+		}
+		if detail.TypeOnly != typeOnly {
+			t.Errorf("Expected %q to have TypeOnly %t", importPath, typeOnly)
+		}
+	}
+	if !tokenizedFile.ImportDetails["src/lazy"].Dynamic {
+		t.Errorf("Expected a dynamic import of a type-only import to be dynamic")
+	}
+	if !slices.Equal(tokenizedFile.Imports["src/theme"], []string{"Theme", "Color"}) {
+		t.Errorf("Expected type imports to keep their identifiers. Got %v", tokenizedFile.Imports["src/theme"])
+	}
+}
+
 func testEdgeList(t *testing.T, edgeList, expected map[string][]string) {
 	if len(edgeList) != len(expected) {
 		t.Errorf("Expected edge list to have length %d but receive %d", len(expected), len(edgeList))
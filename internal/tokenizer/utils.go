@@ -1,6 +1,7 @@
 package tokenizer
 
 import (
+	"regexp"
 	"slices"
 	"strings"
 	"unicode"
@@ -45,3 +46,36 @@ func isQuote(char rune) bool {
 func isRelativePath(path string) bool {
 	return strings.HasPrefix(path, ".")
 }
+
+// Keywords that can be directly followed by an expression
+var expressionKeywords = []string{
+	"return",
+	"yield",
+	"await",
+	"case",
+	"default",
+	"typeof",
+	"instanceof",
+	"void",
+	"delete",
+	"in",
+	"of",
+	"new",
+	"else",
+	"do",
+	"throw",
+}
+
+// Keywords whose parenthesized condition can be directly followed by an expression
+var statementConditionKeywords = []string{"if", "while", "for", "with"}
+
+// Characters after which an expression (e.g. a regular expression or JSX element) can begin
+const expressionStartChars = "(,=:[!&|?{};+-*%<>~^"
+
+func isWordChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_' || char == '$'
+}
+
+// Matches triple-slash reference directives. `lib` references are not included because
+// they refer to built in declaration files rather than files or packages.
+var referenceDirectivePattern = regexp.MustCompile(`^///\s*<reference\s+(path|types)\s*=\s*["']([^"']*)["']`)
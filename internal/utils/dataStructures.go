@@ -59,3 +59,29 @@ func (s Set[T]) Keys() []T {
 	}
 	return keys
 }
+
+type Stack[T any] struct {
+	stack []T
+}
+
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{make([]T, 0)}
+}
+
+func (s *Stack[T]) Push(key ...T) {
+	s.stack = append(s.stack, key...)
+}
+
+func (s *Stack[T]) Pop() T {
+	updated, out := pop(s.stack)
+	s.stack = updated
+	return out
+}
+
+func (s *Stack[T]) Empty() bool {
+	return len(s.stack) == 0
+}
+
+func (s *Stack[T]) Length() int {
+	return len(s.stack)
+}
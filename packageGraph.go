@@ -0,0 +1,212 @@
+package dependor
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// A graph of workspace packages. Each package maps the packages it imports to the
+// number of file-level edges between the two packages.
+type PackageGraph map[string]map[string]int
+
+// Returns the package graph as an unweighted dependency graph
+func (pg PackageGraph) DependencyGraph() DependencyGraph {
+	dg := make(DependencyGraph, len(pg))
+	for pkg, dependencies := range pg {
+		dg[pkg] = make([]string, 0, len(dependencies))
+		for dependency := range dependencies {
+			dg[pkg] = append(dg[pkg], dependency)
+		}
+		slices.Sort(dg[pkg])
+	}
+	return dg
+}
+
+type PackageDependencyIssueKind int
+
+const (
+	// A package imports a package that its package.json doesn't declare
+	UndeclaredDependency PackageDependencyIssueKind = iota
+	// A package's package.json declares a package that it never imports
+	UnusedDependency
+	// A package's production code imports a package that is only in devDependencies
+	DevDependencyInProduction
+)
+
+func (kind PackageDependencyIssueKind) String() string {
+	switch kind {
+	case UndeclaredDependency:
+		return "undeclared dependency"
+	case UnusedDependency:
+		return "unused dependency"
+	case DevDependencyInProduction:
+		return "devDependency in production code"
+	default:
+		return "unknown"
+	}
+}
+
+// A difference between the package graph and the dependencies declared in package.json
+type PackageDependencyIssue struct {
+	Kind       PackageDependencyIssueKind
+	Package    string
+	Dependency string
+	// The package's files that import the dependency. Empty for unused dependencies
+	// and only production files for devDependencies in production code.
+	Files []string
+}
+
+// Collapses the file graph into a graph of the workspace packages that own the files.
+// Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) PackageGraph() PackageGraph {
+	pg := make(PackageGraph, len(graph.workspacePackages))
+	for name := range graph.workspacePackages {
+		pg[name] = make(map[string]int, 0)
+	}
+	for _, imp := range graph.packageImports() {
+		pg[imp.from][imp.to] = imp.weight
+	}
+	return pg
+}
+
+// Compares the package graph with the workspace dependencies each package.json declares.
+// Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) CheckPackageDependencies() []PackageDependencyIssue {
+	issues := make([]PackageDependencyIssue, 0)
+	imported := make(map[string]map[string]bool, len(graph.workspacePackages))
+	for _, imp := range graph.packageImports() {
+		if imported[imp.from] == nil {
+			imported[imp.from] = make(map[string]bool, 0)
+		}
+		imported[imp.from][imp.to] = true
+		if !graph.workspacePackages[imp.from].declares(imp.to) {
+			issues = append(issues, PackageDependencyIssue{
+				Kind:       UndeclaredDependency,
+				Package:    imp.from,
+				Dependency: imp.to,
+				Files:      imp.files,
+			})
+		}
+	}
+
+	for _, pkg := range graph.workspacePackages {
+		for _, dependency := range pkg.declaredDependencies() {
+			if graph.workspacePackages[dependency] != nil && !imported[pkg.Name][dependency] {
+				issues = append(issues, PackageDependencyIssue{
+					Kind:       UnusedDependency,
+					Package:    pkg.Name,
+					Dependency: dependency,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(issues, comparePackageDependencyIssues)
+	return issues
+}
+
+func comparePackageDependencyIssues(a, b PackageDependencyIssue) int {
+	if a.Package != b.Package {
+		return strings.Compare(a.Package, b.Package)
+	}
+	if a.Dependency != b.Dependency {
+		return strings.Compare(a.Dependency, b.Dependency)
+	}
+	return int(a.Kind - b.Kind)
+}
+
+// Imports of one workspace package by another
+type packageImport struct {
+	from string
+	to   string
+	// the number of file-level edges from one package to the other
+	weight int
+	// the importing package's files that import the other package
+	files []string
+}
+
+func (graph *SingleThreadedGraphParser) packageImports() []packageImport {
+	type packagePair struct{ from, to string }
+	type fileEdge struct{ from, to string }
+	pairEdges := make(map[packagePair]utils.Set[fileEdge], 0)
+	for file, edges := range graph.edgeDetails {
+		from := graph.owningPackage(file)
+		if from == nil {
+			continue
+		}
+		for _, edge := range edges {
+			to := edge.Package
+			if edge.Kind == LocalFile {
+				// relative imports can still reach into another package
+				if owner := graph.owningPackage(edge.To); owner != nil {
+					to = owner.Name
+				}
+			} else if edge.Kind != WorkspacePackage {
+				continue
+			}
+			if to == "" || to == from.Name {
+				continue
+			}
+			pair := packagePair{from.Name, to}
+			if pairEdges[pair] == nil {
+				pairEdges[pair] = make(utils.Set[fileEdge], 0)
+			}
+			pairEdges[pair].Add(fileEdge{file, edge.To})
+		}
+	}
+
+	imports := make([]packageImport, 0, len(pairEdges))
+	for pair, edges := range pairEdges {
+		files := make(utils.Set[string], 0)
+		for edge := range edges {
+			files.Add(edge.from)
+		}
+		importingFiles := files.Keys()
+		slices.Sort(importingFiles)
+		imports = append(imports, packageImport{from: pair.from, to: pair.to, weight: len(edges), files: importingFiles})
+	}
+	return imports
+}
+
+// Returns the workspace package with the deepest directory containing the file
+func (graph *SingleThreadedGraphParser) owningPackage(file string) *Package {
+	var owner *Package
+	for _, pkg := range graph.workspacePackages {
+		if pkg.Directory != "." && !strings.HasPrefix(file, pkg.Directory+"/") {
+			continue
+		}
+		if owner == nil || len(pkg.Directory) > len(owner.Directory) {
+			owner = pkg
+		}
+	}
+	return owner
+}
+
+// Returns the workspace packages that own the changed files along with every package that
+// transitively depends on them, sorted by name. Files that aren't in a workspace package
+// are ignored. Only available after ParseGraph has been called.
+func (graph *SingleThreadedGraphParser) AffectedPackages(changedFiles []string) []*Package {
+	dependents := graph.PackageGraph().DependencyGraph().ReverseEdges()
+	affected := make(utils.Set[string], 0)
+	for _, file := range changedFiles {
+		owner := graph.owningPackage(filepath.Clean(file))
+		if owner == nil || affected.Has(owner.Name) {
+			continue
+		}
+		dependents.Traverse(owner.Name, func(node string) {
+			affected.Add(node)
+		})
+	}
+
+	packages := make([]*Package, 0, len(affected))
+	for _, name := range affected.Keys() {
+		packages = append(packages, graph.workspacePackages[name])
+	}
+	slices.SortFunc(packages, func(a, b *Package) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return packages
+}
@@ -0,0 +1,65 @@
+package dependor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPackageGraph(t *testing.T) {
+	parser, _ := parseFixture(t, "test_workspaces/npm")
+
+	expected := PackageGraph{
+		// the design system's button and arrow icon. Its internal module isn't exported.
+		"web":                {"@org/design-system": 2, "@org/utils": 1, "@org/legacy": 1},
+		"@org/design-system": {"@org/utils": 1},
+		"@org/utils":         {},
+		"@org/legacy":        {},
+	}
+	packageGraph := parser.PackageGraph()
+	if !reflect.DeepEqual(packageGraph, expected) {
+		t.Errorf("Expected package graph %v. Got %v", expected, packageGraph)
+	}
+
+	expectedDependencyGraph := DependencyGraph{
+		"web":                {"@org/design-system", "@org/legacy", "@org/utils"},
+		"@org/design-system": {"@org/utils"},
+		"@org/utils":         {},
+		"@org/legacy":        {},
+	}
+	if dependencyGraph := packageGraph.DependencyGraph(); !reflect.DeepEqual(dependencyGraph, expectedDependencyGraph) {
+		t.Errorf("Expected dependency graph %v. Got %v", expectedDependencyGraph, dependencyGraph)
+	}
+}
+
+func TestCheckPackageDependencies(t *testing.T) {
+	parser, _ := parseFixture(t, "test_workspaces/npm")
+
+	expected := []PackageDependencyIssue{
+		{Kind: UnusedDependency, Package: "@org/legacy", Dependency: "@org/utils"},
+		{Kind: UndeclaredDependency, Package: "web", Dependency: "@org/utils", Files: []string{"apps/web/src/app.tsx"}},
+	}
+	issues := parser.CheckPackageDependencies()
+	if !reflect.DeepEqual(issues, expected) {
+		t.Errorf("Expected issues %+v. Got %+v", expected, issues)
+	}
+}
+
+func TestAffectedPackages(t *testing.T) {
+	parser, _ := parseFixture(t, "test_workspaces/npm")
+
+	tests := map[string][]string{
+		"packages/utils/lib/index.ts":   {"@org/design-system", "@org/utils", "web"},
+		"./packages/legacy/src/main.ts": {"@org/legacy", "web"},
+		"apps/web/package.json":         {"web"},
+		"README.md":                     {},
+	}
+	for changedFile, expected := range tests {
+		names := make([]string, 0)
+		for _, pkg := range parser.AffectedPackages([]string{changedFile}) {
+			names = append(names, pkg.Name)
+		}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("Expected changing %q to affect %v. Got %v", changedFile, expected, names)
+		}
+	}
+}
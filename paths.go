@@ -0,0 +1,91 @@
+package dependor
+
+import (
+	"slices"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// Returns one of the shortest chains of imports from one node to another, starting with
+// `from` and ending with `to`. Returns nil if `from` doesn't depend on `to`. When more
+// than one chain is the shortest, the chain with the first nodes in sorted order is used.
+func (dg DependencyGraph) ShortestPath(from, to string) []string {
+	if from == to {
+		return []string{from}
+	}
+	parents := map[string]string{from: from}
+	workQueue := utils.NewQueue[string]()
+	workQueue.Enqueue(from)
+	for !workQueue.Empty() {
+		currentNode := workQueue.Dequeue()
+		for _, edge := range dg.sortedEdges(currentNode) {
+			if _, seen := parents[edge]; seen {
+				continue
+			}
+			parents[edge] = currentNode
+			if edge == to {
+				path := []string{to}
+				for node := to; node != from; node = parents[node] {
+					path = append(path, parents[node])
+				}
+				slices.Reverse(path)
+				return path
+			}
+			workQueue.Enqueue(edge)
+		}
+	}
+	return nil
+}
+
+// Returns up to `limit` chains of imports from one node to another that don't visit
+// any node twice. A limit less than 1 returns every chain, which can be a very large
+// number in big graphs. Chains are found with a depth-first search, so when there are
+// more than `limit` of them the shortest chains may not be included. The chains that
+// are returned are sorted shortest first.
+func (dg DependencyGraph) AllPaths(from, to string, limit int) [][]string {
+	paths := make([][]string, 0)
+	if from == to {
+		return append(paths, []string{from})
+	}
+	// nodes that can't reach `to` can't be part of a path so they are never explored
+	reachesTarget := make(utils.Set[string], 0)
+	dg.ReverseEdges().Traverse(to, func(node string) {
+		reachesTarget.Add(node)
+	})
+	if !reachesTarget.Has(from) {
+		return paths
+	}
+
+	path := []string{from}
+	onPath := utils.Set[string]{from: true}
+	var search func(node string) bool
+	search = func(node string) bool {
+		for _, edge := range dg.sortedEdges(node) {
+			if edge == to {
+				paths = append(paths, append(slices.Clone(path), to))
+				if limit > 0 && len(paths) >= limit {
+					return false
+				}
+				continue
+			}
+			if onPath.Has(edge) || !reachesTarget.Has(edge) {
+				continue
+			}
+			path = append(path, edge)
+			onPath.Add(edge)
+			keepSearching := search(edge)
+			path = path[:len(path)-1]
+			delete(onPath, edge)
+			if !keepSearching {
+				return false
+			}
+		}
+		return true
+	}
+	search(from)
+
+	slices.SortStableFunc(paths, func(a, b []string) int {
+		return len(a) - len(b)
+	})
+	return paths
+}
@@ -0,0 +1,111 @@
+package dependor
+
+import (
+	"slices"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// Returns the nodes a node imports, sorted. When `transitive` is true, every node it
+// depends on through a chain of imports is included. A node is only its own dependency
+// when it is part of a cycle. Transitive results are memoized for each component, so
+// reusing a condensation for many queries is much faster than traversing the graph.
+// The memoized sets are filled in as they are needed, so this isn't safe for concurrent
+// use, and they can take memory proportional to the square of the number of components.
+func (c *Condensation) Dependencies(node string, transitive bool) []string {
+	return c.DependenciesOf([]string{node}, transitive)
+}
+
+// Returns the nodes that import a node, sorted. When `transitive` is true, every node
+// that depends on it through a chain of imports is included.
+func (c *Condensation) Dependents(node string, transitive bool) []string {
+	return c.DependentsOf([]string{node}, transitive)
+}
+
+// Returns every node that any of the nodes depends on, sorted
+func (c *Condensation) DependenciesOf(nodes []string, transitive bool) []string {
+	if !transitive {
+		return directNeighbors(c.graph, nodes)
+	}
+	if c.reachesDependencies == nil {
+		c.reachesDependencies = make([]utils.Set[int], len(c.Components))
+	}
+	return c.reachableNodes(nodes, c.Edges, c.reachesDependencies)
+}
+
+// Returns every node that depends on any of the nodes, sorted
+func (c *Condensation) DependentsOf(nodes []string, transitive bool) []string {
+	if c.reversed == nil {
+		c.reversed = c.graph.ReverseEdges()
+	}
+	if !transitive {
+		return directNeighbors(c.reversed, nodes)
+	}
+	if c.dependentEdges == nil {
+		c.dependentEdges = make([][]int, len(c.Components))
+		for component, edges := range c.Edges {
+			for _, edge := range edges {
+				c.dependentEdges[edge] = append(c.dependentEdges[edge], component)
+			}
+		}
+		c.reachedByDependents = make([]utils.Set[int], len(c.Components))
+	}
+	return c.reachableNodes(nodes, c.dependentEdges, c.reachedByDependents)
+}
+
+// Returns the nodes in every component reachable from the nodes' components using
+// `edges`. Components in a cycle can reach themselves.
+func (c *Condensation) reachableNodes(nodes []string, edges [][]int, memo []utils.Set[int]) []string {
+	var reach func(component int) utils.Set[int]
+	reach = func(component int) utils.Set[int] {
+		if memo[component] != nil {
+			return memo[component]
+		}
+		reachable := make(utils.Set[int], 0)
+		for _, edge := range edges[component] {
+			reachable.Add(edge)
+			for reachableComponent := range reach(edge) {
+				reachable.Add(reachableComponent)
+			}
+		}
+		memo[component] = reachable
+		return reachable
+	}
+
+	components := make(utils.Set[int], 0)
+	for _, node := range nodes {
+		component, ok := c.componentOf[node]
+		if !ok {
+			continue
+		}
+		if c.isCycle(component) {
+			components.Add(component)
+		}
+		for reachableComponent := range reach(component) {
+			components.Add(reachableComponent)
+		}
+	}
+
+	reachableNodes := make([]string, 0)
+	for component := range components {
+		reachableNodes = append(reachableNodes, c.Components[component]...)
+	}
+	slices.Sort(reachableNodes)
+	return reachableNodes
+}
+
+// Whether a component's nodes can reach themselves
+func (c *Condensation) isCycle(component int) bool {
+	nodes := c.Components[component]
+	return len(nodes) > 1 || slices.Contains(c.graph[nodes[0]], nodes[0])
+}
+
+// Returns the nodes any of the nodes has an edge to, sorted without duplicates
+func directNeighbors(graph DependencyGraph, nodes []string) []string {
+	neighbors := make([]string, 0)
+	for _, node := range nodes {
+		neighbors = append(neighbors, graph[node]...)
+	}
+	slices.Sort(neighbors)
+	return slices.Compact(neighbors)
+}
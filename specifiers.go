@@ -0,0 +1,95 @@
+package dependor
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Modules that ship with Node.js. Some newer modules can only be imported with the
+// "node:" prefix so they are only treated as builtins when the prefix is used.
+var nodeBuiltins = []string{
+	"assert",
+	"async_hooks",
+	"buffer",
+	"child_process",
+	"cluster",
+	"console",
+	"constants",
+	"crypto",
+	"dgram",
+	"diagnostics_channel",
+	"dns",
+	"domain",
+	"events",
+	"fs",
+	"http",
+	"http2",
+	"https",
+	"inspector",
+	"module",
+	"net",
+	"os",
+	"path",
+	"perf_hooks",
+	"process",
+	"punycode",
+	"querystring",
+	"readline",
+	"repl",
+	"stream",
+	"string_decoder",
+	"sys",
+	"timers",
+	"tls",
+	"trace_events",
+	"tty",
+	"url",
+	"util",
+	"v8",
+	"vm",
+	"wasi",
+	"worker_threads",
+	"zlib",
+}
+
+var prefixOnlyNodeBuiltins = []string{
+	"sea",
+	"sqlite",
+	"test",
+}
+
+// Returns the builtin module name for specifiers like "fs", "fs/promises" or "node:test"
+func nodeBuiltinName(specifier string) (string, bool) {
+	name, hasPrefix := strings.CutPrefix(specifier, "node:")
+	name, _, _ = strings.Cut(name, "/")
+	if slices.Contains(nodeBuiltins, name) || (hasPrefix && slices.Contains(prefixOnlyNodeBuiltins, name)) {
+		return name, true
+	}
+	return "", false
+}
+
+var packageNamePattern = regexp.MustCompile(`^(@[a-zA-Z0-9~-][a-zA-Z0-9._~-]*/)?[a-zA-Z0-9~-][a-zA-Z0-9._~-]*$`)
+
+// Returns the name of the package a bare specifier imports from. Deep imports are
+// normalized to their package so "lodash/fp" becomes "lodash" and "@scope/pkg/utils"
+// becomes "@scope/pkg".
+func packageName(specifier string) (string, bool) {
+	parts := strings.SplitN(specifier, "/", 3)
+	name := parts[0]
+	if strings.HasPrefix(name, "@") {
+		if len(parts) < 2 {
+			return "", false
+		}
+		name += "/" + parts[1]
+	}
+	if !packageNamePattern.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// Relative and absolute specifiers always refer to files rather than packages
+func isPathSpecifier(specifier string) bool {
+	return strings.HasPrefix(specifier, ".") || strings.HasPrefix(specifier, "/")
+}
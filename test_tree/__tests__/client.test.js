@@ -0,0 +1,11 @@
+import { doStuff } from "../util/c";
+
+jest.mock("../util/c");
+const actual = jest.requireActual("../b");
+vi.mock("~/a", () => ({ default: "mocked" }));
+// not a module reference call so this should be ignored
+jest.fn("../not/a/module");
+
+test("does stuff", () => {
+  expect(doStuff()).toBe(actual);
+});
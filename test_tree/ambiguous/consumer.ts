@@ -0,0 +1,4 @@
+import { foo } from "./foo";
+import { bar } from "./bar";
+
+export const both = foo + bar;
@@ -0,0 +1,7 @@
+import "express";
+
+declare module "express" {
+  interface Request {
+    user?: string;
+  }
+}
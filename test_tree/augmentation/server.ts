@@ -0,0 +1,5 @@
+import express from "express";
+import { ref } from "vue";
+
+export const app = express();
+export const count = ref(0);
@@ -0,0 +1,7 @@
+export {};
+
+declare module "vue" {
+  interface ComponentCustomProperties {
+    $translate: (key: string) => string;
+  }
+}
@@ -0,0 +1,3 @@
+export default function Draft() {
+  return <h1>Not ready to import yet</h1>;
+}
@@ -0,0 +1,8 @@
+// Vite glob imports
+const pages = import.meta.glob(["./pages/*.tsx", "!./pages/_*.tsx"]);
+const routes = import.meta.glob("./routes/**/index.ts");
+// webpack context modules
+const icons = require.context("./icons", false, /\.svg$/);
+const worker = new Worker(require.resolve("./worker"));
+
+export { pages, routes, icons, worker };
@@ -0,0 +1,3 @@
+import { Panel } from "./panel";
+
+export const Settings = Panel;
@@ -0,0 +1 @@
+self.onmessage = (event) => self.postMessage(event.data);
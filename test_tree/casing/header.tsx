@@ -0,0 +1,3 @@
+import { Button } from "./button";
+
+export const Header = () => <header>{Button({ label: "Home" })}</header>;
@@ -0,0 +1,4 @@
+import type { View } from "./view";
+import { render } from "./view";
+
+export const store = { views: [] as View[], render };
@@ -0,0 +1,4 @@
+import type { View } from "./view";
+import { store } from "./store";
+
+export type Store = typeof store & { current?: View };
@@ -0,0 +1,4 @@
+import type { Store } from "./types";
+
+export type View = { store: Store };
+export const render = () => import("./store");
@@ -0,0 +1,2 @@
+const legacy = (value: string) => value;
+export = legacy;
@@ -0,0 +1,6 @@
+import { format } from "./util.js";
+import { View } from "./view.jsx";
+import legacy from "./legacy.cjs";
+import { helper } from "./helper.mjs";
+
+export const render = () => View(format(legacy(helper())));
@@ -0,0 +1 @@
+export const format = (value: string): string => value.trim();
@@ -0,0 +1 @@
+export const View = (text: string) => <p>{text}</p>;
@@ -0,0 +1,3 @@
+export const loadLocale = (lang: string) => import(`./locales/${lang}.json`);
+export const loadPage = (name: string) => import("./bundler/pages/" + name);
+export const loadWorker = () => import("./bundler/worker");
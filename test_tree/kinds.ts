@@ -0,0 +1,11 @@
+import path from "node:path";
+import { readFile } from "fs/promises";
+import { test } from "node:test";
+import { map } from "lodash/fp";
+import { ReactQueryDevtools } from "@tanstack/react-query-devtools/production";
+import { Button } from "@acme/ui/button";
+import { value } from "./missing";
+import { other } from "~/also-missing";
+import a from "./a";
+
+export const kinds = [path, readFile, test, map, ReactQueryDevtools, Button, value, other, a];
@@ -0,0 +1,5 @@
+import * as widgets from "./widgets";
+
+export const loadPage = (name: string) => import(`./pages/${name}/index`);
+export const loadWidgets = () => import("./widgets");
+export { widgets };
@@ -0,0 +1 @@
+export const Header = "header";
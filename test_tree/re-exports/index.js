@@ -1,3 +1,4 @@
 export { default as rexa } from "./rexa";
 export { default as rexb } from "./rexb";
 export * from "./rexc";
+export { rexc as "quoted rexc" } from "./rexc";
@@ -1,5 +1,6 @@
 import { rexa } from "../../../re-exports";
 import defaultImport from "../../../re-exports/rexb";
+import { "quoted rexc" as quoted } from "../../../re-exports";
 
 export const veggies = [
   "kale",
@@ -0,0 +1,9 @@
+/// <reference path="./globals.d.ts" />
+/// <reference types="node" />
+/// <reference lib="es2017" />
+import fs = require("fs");
+import Mode = Config.mode;
+import { mode } from "virtual:config";
+import logo from "./logo.svg";
+
+export const readLogo = () => fs.readFileSync(logo, mode);
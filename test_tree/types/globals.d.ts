@@ -0,0 +1,8 @@
+declare module "virtual:config" {
+  export const mode: string;
+}
+
+declare module "*.svg" {
+  const content: string;
+  export default content;
+}
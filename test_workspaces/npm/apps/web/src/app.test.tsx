@@ -0,0 +1,8 @@
+import { expect, test } from "vitest";
+import { format } from "date-fns";
+import { App } from "./app";
+
+test("renders", () => {
+  expect(App).toBeDefined();
+  expect(format).toBeDefined();
+});
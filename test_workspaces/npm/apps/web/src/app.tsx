@@ -0,0 +1,12 @@
+import React from "react";
+import { format } from "date-fns";
+import { Button } from "@org/design-system";
+import { Button as DirectButton } from "@org/design-system/button";
+import { Arrow } from "@org/design-system/icons/arrow";
+import { secret } from "@org/design-system/internal/secret";
+import { classNames } from "@org/utils";
+import { legacy } from "@org/legacy";
+import { ignored } from "@org/ignored";
+import { tool } from "@org/tools";
+
+export const App = () => <main className={classNames("app")}>{[Button, DirectButton, Arrow, secret, legacy, ignored, tool, React, format]}</main>;
@@ -0,0 +1,7 @@
+import type { Duration } from "date-fns";
+import type { Request } from "express";
+import lodash from "lodash";
+
+export const debounceRequest = (handler: (request: Request) => void) => lodash.debounce(handler, 100);
+
+export const toMilliseconds = (duration: Duration) => (duration.seconds ?? 0) * 1000;
@@ -0,0 +1,3 @@
+import { classNames } from "@org/utils";
+
+export const Button = ({ label }: { label: string }) => <button className={classNames("button")}>{label}</button>;
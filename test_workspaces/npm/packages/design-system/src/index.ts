@@ -0,0 +1 @@
+export { Button } from "./button";
@@ -0,0 +1 @@
+export const ignored = true;
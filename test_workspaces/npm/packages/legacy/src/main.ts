@@ -0,0 +1 @@
+export const legacy = true;
@@ -0,0 +1 @@
+export const classNames = (...names: string[]) => names.join(" ");
@@ -0,0 +1 @@
+export const tool = true;
@@ -0,0 +1 @@
+export const demo = "demo";
@@ -0,0 +1,4 @@
+import { a } from "a-lib";
+import { demo } from "demo";
+
+export const b = a + demo;
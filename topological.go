@@ -0,0 +1,99 @@
+package dependor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Returned when a graph that needs to be acyclic has a cycle
+type CycleError struct {
+	// The nodes in the cycle. The first node is repeated at the end.
+	Cycle []string
+}
+
+func (err *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(err.Cycle, " -> "))
+}
+
+// Groups the graph's nodes into levels where each node only depends on nodes in earlier
+// levels. Nodes in the same level don't depend on each other, so they can be built in
+// parallel once the previous levels are done. Nodes that are only imported (e.g. external
+// packages) are included. Each level is sorted. If the graph has a cycle, a *CycleError
+// is returned.
+func (dg DependencyGraph) TopologicalLevels() ([][]string, error) {
+	// the number of unfinished dependencies each node has
+	remaining := make(map[string]int, len(dg))
+	dependents := make(map[string][]string, len(dg))
+	for node := range dg {
+		if _, ok := remaining[node]; !ok {
+			remaining[node] = 0
+		}
+		for _, edge := range dg.sortedEdges(node) {
+			if _, ok := remaining[edge]; !ok {
+				remaining[edge] = 0
+			}
+			remaining[node]++
+			dependents[edge] = append(dependents[edge], node)
+		}
+	}
+
+	var level []string
+	for node, count := range remaining {
+		if count == 0 {
+			level = append(level, node)
+		}
+	}
+
+	levels := make([][]string, 0)
+	finished := 0
+	for len(level) > 0 {
+		slices.Sort(level)
+		levels = append(levels, level)
+		finished += len(level)
+		var next []string
+		for _, node := range level {
+			for _, dependent := range dependents[node] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		level = next
+	}
+
+	if finished < len(remaining) {
+		return nil, &CycleError{Cycle: dg.findUnfinishedCycle(remaining)}
+	}
+	return levels, nil
+}
+
+// Every node that a topological sort couldn't finish depends on another unfinished
+// node, so following unfinished dependencies from any of them must lead to a cycle.
+func (dg DependencyGraph) findUnfinishedCycle(remaining map[string]int) []string {
+	var unfinished []string
+	for node, count := range remaining {
+		if count > 0 {
+			unfinished = append(unfinished, node)
+		}
+	}
+	slices.Sort(unfinished)
+
+	path := []string{unfinished[0]}
+	positions := map[string]int{unfinished[0]: 0}
+	for {
+		current := path[len(path)-1]
+		for _, edge := range dg.sortedEdges(current) {
+			if remaining[edge] == 0 {
+				continue
+			}
+			if start, ok := positions[edge]; ok {
+				return append(path[start:], edge)
+			}
+			positions[edge] = len(path)
+			path = append(path, edge)
+			break
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package dependor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The steps taken to resolve an import specifier, similar to `tsc --traceResolution`
+type ResolutionTrace struct {
+	// The file the import is written in
+	File      string
+	Specifier string
+	Steps     []string
+	// The files or modules the specifier resolved to. Imports from index files
+	// can resolve to more than one file.
+	Result []string
+}
+
+// Records a step. Resolution functions are passed a nil trace when tracing
+// is turned off so this is safe to call on a nil trace.
+func (trace *ResolutionTrace) add(format string, args ...any) {
+	if trace == nil {
+		return
+	}
+	trace.Steps = append(trace.Steps, fmt.Sprintf(format, args...))
+}
+
+func (trace *ResolutionTrace) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Resolving %q from %q\n", trace.Specifier, trace.File)
+	for _, step := range trace.Steps {
+		fmt.Fprintf(&builder, "  %s\n", step)
+	}
+	fmt.Fprintf(&builder, "Resolved to %s", strings.Join(quoteAll(trace.Result), ", "))
+	return builder.String()
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return quoted
+}
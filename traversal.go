@@ -0,0 +1,164 @@
+package dependor
+
+import (
+	"slices"
+
+	"github.com/stilt0n/dependor/internal/utils"
+)
+
+// The order nodes are visited in by TraverseWith
+type TraversalOrder int
+
+const (
+	// Visits every node at one depth before visiting nodes at the next depth
+	BreadthFirst TraversalOrder = iota
+	// Visits everything below a node before visiting the node's siblings
+	DepthFirst
+)
+
+// The edges TraverseWith follows
+type TraversalDirection int
+
+const (
+	// Follows edges from files to the files they import
+	FollowDependencies TraversalDirection = iota
+	// Follows edges from files to the files that import them
+	FollowDependents
+)
+
+// What a traversal should do after visiting a node
+type VisitResult int
+
+const (
+	// Visit the node's edges
+	Continue VisitResult = iota
+	// Don't visit the node's edges, but keep visiting other nodes
+	Skip
+	// End the traversal
+	Stop
+)
+
+// A node visited by TraverseWith
+type Visit struct {
+	Node string
+	// The number of edges between the starting node and Node
+	Depth int
+	// The node whose edge led to Node. Empty for the starting node. When following
+	// dependents, Node imports Parent.
+	Parent string
+}
+
+type TraversalOptions struct {
+	// Defaults to BreadthFirst
+	Order TraversalOrder
+	// Defaults to FollowDependencies
+	Direction TraversalDirection
+	// The deepest nodes to visit. Zero visits every depth.
+	MaxDepth int
+	// Returns whether an edge should be followed. `from` imports `to` even when following
+	// dependents. When nil every edge is followed.
+	FollowEdge func(from, to string) bool
+}
+
+type traversalStep struct {
+	node   string
+	depth  int
+	parent string
+}
+
+// Traverses the graph starting from `startingNode` and calls `visit` on each node it reaches.
+// Each node is visited once. `visit` returns whether to continue to the node's edges, skip
+// them or stop the traversal. In depth-first order with a MaxDepth, nodes are only reached
+// along their shortest paths from the starting node so that none within MaxDepth are missed.
+func (dg DependencyGraph) TraverseWith(startingNode string, options TraversalOptions, visit func(visit Visit) VisitResult) {
+	graph := dg
+	if options.Direction == FollowDependents {
+		graph = dg.ReverseEdges()
+		// reversed edges are in no particular order so they are sorted to keep traversals stable
+		for _, edges := range graph {
+			slices.Sort(edges)
+		}
+	}
+	// returns the steps for a node's edges that the options allow
+	next := func(step traversalStep) []traversalStep {
+		if options.MaxDepth > 0 && step.depth >= options.MaxDepth {
+			return nil
+		}
+		var steps []traversalStep
+		for _, edge := range graph[step.node] {
+			from, to := step.node, edge
+			if options.Direction == FollowDependents {
+				from, to = to, from
+			}
+			if options.FollowEdge == nil || options.FollowEdge(from, to) {
+				steps = append(steps, traversalStep{node: edge, depth: step.depth + 1, parent: step.node})
+			}
+		}
+		return steps
+	}
+
+	start := traversalStep{node: startingNode}
+	seen := make(utils.Set[string], 0)
+	if options.Order == DepthFirst {
+		// A node reached deep in the search first could hide nodes below it that are within
+		// MaxDepth through a shorter path, so each node's shortest depth is found first and
+		// edges are only followed when they lead to a node at its shortest depth.
+		var shortestDepths map[string]int
+		if options.MaxDepth > 0 {
+			shortestDepths = map[string]int{startingNode: 0}
+			depthQueue := utils.NewQueue[traversalStep]()
+			depthQueue.Enqueue(start)
+			for !depthQueue.Empty() {
+				for _, step := range next(depthQueue.Dequeue()) {
+					if _, ok := shortestDepths[step.node]; !ok {
+						shortestDepths[step.node] = step.depth
+						depthQueue.Enqueue(step)
+					}
+				}
+			}
+		}
+		workStack := utils.NewStack[traversalStep]()
+		workStack.Push(start)
+		for !workStack.Empty() {
+			current := workStack.Pop()
+			// a node can be pushed more than once, but it is only visited the first time it is popped
+			if seen.Has(current.node) {
+				continue
+			}
+			seen.Add(current.node)
+			switch visit(Visit{Node: current.node, Depth: current.depth, Parent: current.parent}) {
+			case Stop:
+				return
+			case Skip:
+				continue
+			}
+			steps := next(current)
+			if shortestDepths != nil {
+				steps = slices.DeleteFunc(steps, func(step traversalStep) bool { return shortestDepths[step.node] != step.depth })
+			}
+			// pushed in reverse so that the first edge is visited first
+			slices.Reverse(steps)
+			workStack.Push(steps...)
+		}
+		return
+	}
+
+	workQueue := utils.NewQueue[traversalStep]()
+	workQueue.Enqueue(start)
+	seen.Add(startingNode)
+	for !workQueue.Empty() {
+		current := workQueue.Dequeue()
+		switch visit(Visit{Node: current.node, Depth: current.depth, Parent: current.parent}) {
+		case Stop:
+			return
+		case Skip:
+			continue
+		}
+		for _, step := range next(current) {
+			if !seen.Has(step.node) {
+				workQueue.Enqueue(step)
+				seen.Add(step.node)
+			}
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package dependor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// A workspace package whose package.json is part of the project
+type Package struct {
+	Name string
+	// The directory of the package's package.json relative to the project root
+	Directory            string
+	Dependencies         map[string]string
+	DevDependencies      map[string]string
+	PeerDependencies     map[string]string
+	OptionalDependencies map[string]string
+	manifest             packageManifest
+}
+
+// The parts of a package.json that dependor uses
+type packageManifest struct {
+	Name                 string            `json:"name"`
+	Main                 string            `json:"main"`
+	Module               string            `json:"module"`
+	Source               string            `json:"source"`
+	Exports              any               `json:"exports"`
+	Workspaces           any               `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+func readPackageManifest(path string) (packageManifest, error) {
+	var manifest packageManifest
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(contents, &manifest)
+	return manifest, err
+}
+
+// Conditions in package.json `exports` in the order they are preferred. Conditions that
+// usually point to source files come first since build output may not exist.
+var exportConditions = []string{
+	"source",
+	"development",
+	"import",
+	"module",
+	"require",
+	"node",
+	"browser",
+	"default",
+	"types",
+}
+
+// Returns the workspace package globs from package.json's `workspaces` field and
+// pnpm-workspace.yaml. The second return value is false when neither declares any.
+func readWorkspaceGlobs() ([]string, bool) {
+	var globs []string
+	declared := false
+	if manifest, err := readPackageManifest("package.json"); err == nil {
+		switch workspaces := manifest.Workspaces.(type) {
+		case []any:
+			globs, declared = append(globs, stringValues(workspaces)...), true
+		case map[string]any:
+			// yarn's `workspaces: { packages: [...], nohoist: [...] }` form
+			if packages, ok := workspaces["packages"].([]any); ok {
+				globs, declared = append(globs, stringValues(packages)...), true
+			}
+		}
+	}
+	if packages, err := readPnpmWorkspacePackages("pnpm-workspace.yaml"); err == nil {
+		globs, declared = append(globs, packages...), true
+	}
+
+	for i, glob := range globs {
+		negated, isNegated := strings.CutPrefix(glob, "!")
+		negated = strings.TrimSuffix(strings.TrimPrefix(negated, "./"), "/")
+		if isNegated {
+			negated = "!" + negated
+		}
+		globs[i] = negated
+	}
+	return globs, declared
+}
+
+func stringValues(values []any) []string {
+	var strs []string
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+// Reads the `packages` list from a pnpm-workspace.yaml file. This only handles the
+// block list form that pnpm documents rather than all of YAML:
+//
+//	packages:
+//	  - "packages/*"
+//	  - "!**/test/**"
+func readPnpmWorkspacePackages(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var packages []string
+	inPackages := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = line[:comment]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		// keys that aren't indented end the previous key's list
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			inPackages = strings.TrimSpace(strings.TrimSuffix(trimmed, ":")) == "packages"
+			continue
+		}
+		if item, ok := strings.CutPrefix(trimmed, "-"); ok && inPackages {
+			packages = append(packages, strings.Trim(strings.TrimSpace(item), `"'`))
+		}
+	}
+	return packages, scanner.Err()
+}
+
+// Workspace packages are found using the project's `workspaces` or pnpm-workspace.yaml
+// globs. Projects that don't declare workspaces can import any package with a
+// package.json in the project by name.
+func (graph *SingleThreadedGraphParser) findWorkspacePackages() {
+	globs, declared := readWorkspaceGlobs()
+	var included, excluded []string
+	for _, glob := range globs {
+		if negated, ok := strings.CutPrefix(glob, "!"); ok {
+			excluded = append(excluded, negated)
+			continue
+		}
+		included = append(included, glob)
+	}
+
+	for _, file := range graph.files.Keys() {
+		if filepath.Base(file) != "package.json" {
+			continue
+		}
+		directory := filepath.Dir(file)
+		if declared && (!matchesAnyGlob(included, directory) || matchesAnyGlob(excluded, directory)) {
+			continue
+		}
+		manifest, err := readPackageManifest(file)
+		if err != nil {
+			fmt.Printf("WARN: Skipping %q because it could not be read. See error: %s\n", file, err)
+			continue
+		}
+		if manifest.Name == "" {
+			continue
+		}
+		graph.workspacePackages[manifest.Name] = &Package{
+			Name:                 manifest.Name,
+			Directory:            directory,
+			Dependencies:         manifest.Dependencies,
+			DevDependencies:      manifest.DevDependencies,
+			PeerDependencies:     manifest.PeerDependencies,
+			OptionalDependencies: manifest.OptionalDependencies,
+			manifest:             manifest,
+		}
+	}
+}
+
+// Returns the names of every dependency declared in package.json
+func (pkg *Package) declaredDependencies() []string {
+	var names []string
+	for _, dependencies := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for name := range dependencies {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func (pkg *Package) declares(dependency string) bool {
+	return slices.Contains(pkg.declaredDependencies(), dependency)
+}
+
+// Resolves imports of workspace packages like "@org/ui" or "@org/ui/button" to
+// files in the package.
+func (graph *SingleThreadedGraphParser) resolveWorkspaceImport(path string, trace *ResolutionTrace) (string, bool) {
+	if isPathSpecifier(path) {
+		return "", false
+	}
+	if _, _, isAliased := graph.config.MatchAlias(path); isAliased {
+		return "", false
+	}
+	name, ok := packageName(path)
+	if !ok {
+		return "", false
+	}
+	pkg, ok := graph.workspacePackages[name]
+	if !ok {
+		return "", false
+	}
+
+	subpath := "." + strings.TrimPrefix(path, name)
+	trace.add("%q is the workspace package in %q", name, pkg.Directory)
+	for _, entry := range pkg.entryPoints(subpath) {
+		trace.add("Trying entry point %q of %q", entry, name)
+		resolved := withExtension(graph.tokens, graph.config, filepath.Join(pkg.Directory, entry), trace)
+		if graph.files.Has(resolved) {
+			trace.add("Found file %q", resolved)
+			return resolved, true
+		}
+	}
+	trace.add("No entry point of %q exists for %q", name, subpath)
+	return "", false
+}
+
+// Returns the workspace package and subpath of an import like "@org/ui/internal" when the
+// package's `exports` field doesn't export the subpath. These imports fail in Node and
+// bundlers even when the file exists.
+func (graph *SingleThreadedGraphParser) unexportedWorkspaceSubpath(specifier string) (string, string, bool) {
+	name, ok := packageName(specifier)
+	if !ok {
+		return "", "", false
+	}
+	pkg, ok := graph.workspacePackages[name]
+	if !ok || pkg.manifest.Exports == nil {
+		return "", "", false
+	}
+	subpath := "." + strings.TrimPrefix(specifier, name)
+	if len(exportTargets(pkg.manifest.Exports, subpath)) > 0 {
+		return "", "", false
+	}
+	return name, subpath, true
+}
+
+// Returns the paths relative to the package directory that a subpath like "." or
+// "./button" could refer to, in the order they should be tried.
+func (pkg *Package) entryPoints(subpath string) []string {
+	var entries []string
+	if subpath == "." && pkg.manifest.Source != "" {
+		entries = append(entries, pkg.manifest.Source)
+	}
+	if pkg.manifest.Exports != nil {
+		entries = append(entries, exportTargets(pkg.manifest.Exports, subpath)...)
+	}
+	if subpath != "." {
+		// packages with `exports` can only be deep imported through them
+		if pkg.manifest.Exports != nil {
+			return entries
+		}
+		return append(entries, subpath)
+	}
+	for _, entry := range []string{pkg.manifest.Main, pkg.manifest.Module} {
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return append(entries, "index", "src/index")
+}
+
+// Returns the targets package.json `exports` has for a subpath in order of preference
+func exportTargets(exports any, subpath string) []string {
+	subpaths, ok := exports.(map[string]any)
+	isSubpathMap := ok && slices.ContainsFunc(mapKeys(subpaths), func(key string) bool {
+		return strings.HasPrefix(key, ".")
+	})
+	if !isSubpathMap {
+		// `exports` is a string, array or conditions and only applies to the package's root
+		if subpath == "." {
+			return conditionTargets(exports)
+		}
+		return nil
+	}
+
+	if target, ok := subpaths[subpath]; ok {
+		return conditionTargets(target)
+	}
+	// Like TypeScript, we prefer the pattern with the longest prefix e.g. "./icons/*"
+	bestMatch, bestPrefixLength := "", -1
+	for pattern := range subpaths {
+		prefix, suffix, hasWildcard := strings.Cut(pattern, "*")
+		if !hasWildcard || len(prefix) <= bestPrefixLength {
+			continue
+		}
+		if len(subpath) >= len(prefix)+len(suffix) && strings.HasPrefix(subpath, prefix) && strings.HasSuffix(subpath, suffix) {
+			bestMatch, bestPrefixLength = pattern, len(prefix)
+		}
+	}
+	if bestPrefixLength < 0 {
+		return nil
+	}
+	prefix, suffix, _ := strings.Cut(bestMatch, "*")
+	replacement := subpath[len(prefix) : len(subpath)-len(suffix)]
+	targets := conditionTargets(subpaths[bestMatch])
+	for i, target := range targets {
+		targets[i] = strings.ReplaceAll(target, "*", replacement)
+	}
+	return targets
+}
+
+func conditionTargets(target any) []string {
+	switch target := target.(type) {
+	case string:
+		return []string{target}
+	case []any:
+		var targets []string
+		for _, fallback := range target {
+			targets = append(targets, conditionTargets(fallback)...)
+		}
+		return targets
+	case map[string]any:
+		var targets []string
+		for _, condition := range exportConditions {
+			if conditionTarget, ok := target[condition]; ok {
+				targets = append(targets, conditionTargets(conditionTarget)...)
+			}
+		}
+		return targets
+	default:
+		// null targets make a subpath private
+		return nil
+	}
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,139 @@
+package dependor
+
+import (
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// NewSync changes directories to the root path so this changes back when the test ends
+func parseFixture(t *testing.T, rootPath string) (*SingleThreadedGraphParser, DependencyGraph) {
+	t.Helper()
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(workingDirectory); err != nil {
+			t.Fatal(err)
+		}
+	})
+	parser := NewSync(rootPath)
+	graph, err := parser.ParseGraph()
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %s\n", err)
+	}
+	return parser, graph
+}
+
+func TestWorkspaceImports(t *testing.T) {
+	parser, graph := parseFixture(t, "test_workspaces/npm")
+
+	expected := map[string]Edge{
+		"react":    {Kind: ExternalPackage, Package: "react"},
+		"date-fns": {Kind: ExternalPackage, Package: "date-fns"},
+		// index files are resolved to the files that export the imported names
+		"packages/design-system/src/button.tsx":      {Kind: WorkspacePackage, Package: "@org/design-system"},
+		"packages/design-system/src/icons/arrow.tsx": {Kind: WorkspacePackage, Package: "@org/design-system"},
+		// `exports` makes the internal directory private
+		"@org/design-system/internal/secret": {Kind: Unresolved},
+		"packages/utils/lib/index.ts":        {Kind: WorkspacePackage, Package: "@org/utils"},
+		"packages/legacy/src/main.ts":        {Kind: WorkspacePackage, Package: "@org/legacy"},
+		"@org/ignored":                       {Kind: ExternalPackage, Package: "@org/ignored"},
+		"@org/tools":                         {Kind: ExternalPackage, Package: "@org/tools"},
+	}
+	edges := parser.Edges("apps/web/src/app.tsx")
+	// the button is imported both through the package's index file and directly
+	if len(edges) != len(expected)+1 {
+		t.Fatalf("Expected %d edges but received %d: %v", len(expected)+1, len(edges), graph["apps/web/src/app.tsx"])
+	}
+	for _, edge := range edges {
+		expectedEdge, ok := expected[edge.To]
+		if !ok {
+			t.Errorf("Unexpected edge to %q", edge.To)
+			continue
+		}
+		if edge.Kind != expectedEdge.Kind || edge.Package != expectedEdge.Package {
+			t.Errorf("Expected edge to %q to be %s %q. Got %s %q", edge.To, expectedEdge.Kind, expectedEdge.Package, edge.Kind, edge.Package)
+		}
+	}
+
+	expectedUnresolved := []UnresolvedImport{{
+		File:      "apps/web/src/app.tsx",
+		Specifier: "@org/design-system/internal/secret",
+		Line:      6,
+		Column:    24,
+		Reason:    `"./internal/secret" is not exported by "@org/design-system"`,
+	}}
+	if unresolved := parser.UnresolvedImports(); !reflect.DeepEqual(unresolved, expectedUnresolved) {
+		t.Errorf("Expected unresolved imports %+v. Got %+v", expectedUnresolved, unresolved)
+	}
+
+	if !slices.Equal(graph["packages/design-system/src/button.tsx"], []string{"packages/utils/lib/index.ts"}) {
+		t.Errorf("Expected cross-package edge from the design system to utils. Got %v", graph["packages/design-system/src/button.tsx"])
+	}
+
+	var names []string
+	for _, pkg := range parser.WorkspacePackages() {
+		names = append(names, pkg.Name)
+	}
+	expectedNames := []string{"@org/design-system", "@org/legacy", "@org/utils", "web"}
+	if !slices.Equal(names, expectedNames) {
+		t.Errorf("Expected workspace packages %v. Got %v", expectedNames, names)
+	}
+}
+
+func TestPnpmWorkspace(t *testing.T) {
+	parser, graph := parseFixture(t, "test_workspaces/pnpm")
+
+	if !slices.Equal(graph["packages/b/index.js"], []string{"packages/a/index.js", "demo"}) && !slices.Equal(graph["packages/b/index.js"], []string{"demo", "packages/a/index.js"}) {
+		t.Errorf("Expected b-lib to import a-lib's entry and the excluded demo package. Got %v", graph["packages/b/index.js"])
+	}
+	for _, edge := range parser.Edges("packages/b/index.js") {
+		if edge.To == "demo" && edge.Kind != ExternalPackage {
+			t.Errorf("Expected excluded package to be external. Got %s", edge.Kind)
+		}
+	}
+}
+
+func TestReadPnpmWorkspacePackages(t *testing.T) {
+	packages, err := readPnpmWorkspacePackages("test_workspaces/pnpm/pnpm-workspace.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"packages/*", "examples/**", "!examples/demo"}
+	if !slices.Equal(packages, expected) {
+		t.Errorf("Expected %v. Got %v", expected, packages)
+	}
+}
+
+func TestExportTargets(t *testing.T) {
+	exports := map[string]any{
+		".":         map[string]any{"types": "./index.d.ts", "import": "./esm/index.js", "source": "./src/index.ts"},
+		"./utils":   []any{"./src/utils.ts", "./dist/utils.js"},
+		"./icons/*": "./src/icons/*.tsx",
+		"./*":       "./src/*.ts",
+		"./private": nil,
+	}
+	tests := map[string][]string{
+		".":              {"./src/index.ts", "./esm/index.js", "./index.d.ts"},
+		"./utils":        {"./src/utils.ts", "./dist/utils.js"},
+		"./icons/arrow":  {"./src/icons/arrow.tsx"},
+		"./other":        {"./src/other.ts"},
+		"./private":      nil,
+		"./package.json": {"./src/package.json.ts"},
+	}
+	for subpath, expected := range tests {
+		if targets := exportTargets(exports, subpath); !slices.Equal(targets, expected) {
+			t.Errorf("Expected %q to have targets %v. Got %v", subpath, expected, targets)
+		}
+	}
+
+	if targets := exportTargets("./index.js", "."); !slices.Equal(targets, []string{"./index.js"}) {
+		t.Errorf("Expected string exports to apply to the root. Got %v", targets)
+	}
+	if targets := exportTargets("./index.js", "./utils"); targets != nil {
+		t.Errorf("Expected string exports not to apply to subpaths. Got %v", targets)
+	}
+}